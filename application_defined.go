@@ -5,6 +5,7 @@ package rtcp
 
 import (
 	"encoding/binary"
+	"sync"
 )
 
 // ApplicationDefined represents an RTCP application-defined packet.
@@ -121,3 +122,116 @@ func (a *ApplicationDefined) MarshalSize() int {
 
 	return 12 + dataLength + paddingSize
 }
+
+// ApplicationPayload is a typed representation of the application-dependent
+// data carried by an ApplicationDefined packet. Implementations are
+// registered per name and subtype with RegisterApplicationPayload.
+type ApplicationPayload interface {
+	// Marshal encodes the payload into the application-dependent data.
+	Marshal() ([]byte, error)
+	// Unmarshal decodes the payload from the application-dependent data,
+	// excluding any padding.
+	Unmarshal(data []byte) error
+}
+
+type applicationPayloadKey struct {
+	Name    string
+	SubType uint8
+}
+
+//nolint:gochecknoglobals
+var applicationPayloadRegistry = struct {
+	sync.RWMutex
+	payloads map[applicationPayloadKey]func() ApplicationPayload
+}{payloads: map[applicationPayloadKey]func() ApplicationPayload{}}
+
+// RegisterApplicationPayload registers a constructor for the payload of
+// ApplicationDefined packets with the given name and subtype, so that
+// ApplicationDefined.Payload can decode them. The constructor must return
+// a new, empty payload on every call. Each combination of name and subtype
+// may only be registered once.
+func RegisterApplicationPayload(name string, subType uint8, newPayload func() ApplicationPayload) error {
+	if !isApplicationName(name) {
+		return errAppDefinedInvalidName
+	}
+	if subType > countMax {
+		return errInvalidHeader
+	}
+	if newPayload == nil {
+		return errNilApplicationPayloadConstructor
+	}
+
+	applicationPayloadRegistry.Lock()
+	defer applicationPayloadRegistry.Unlock()
+
+	key := applicationPayloadKey{Name: name, SubType: subType}
+	if _, ok := applicationPayloadRegistry.payloads[key]; ok {
+		return errAppDefinedPayloadRegistered
+	}
+	applicationPayloadRegistry.payloads[key] = newPayload
+
+	return nil
+}
+
+// UnregisterApplicationPayload removes a constructor previously added with
+// RegisterApplicationPayload.
+func UnregisterApplicationPayload(name string, subType uint8) {
+	applicationPayloadRegistry.Lock()
+	defer applicationPayloadRegistry.Unlock()
+
+	delete(applicationPayloadRegistry.payloads, applicationPayloadKey{Name: name, SubType: subType})
+}
+
+// NewApplicationDefined returns an ApplicationDefined packet whose data is
+// the encoded payload.
+func NewApplicationDefined(
+	ssrc uint32,
+	name string,
+	subType uint8,
+	payload ApplicationPayload,
+) (*ApplicationDefined, error) {
+	data, err := payload.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApplicationDefined{
+		SubType: subType,
+		SSRC:    ssrc,
+		Name:    name,
+		Data:    data,
+	}, nil
+}
+
+// Payload decodes the application-dependent data using the payload
+// registered for the packet's name and subtype.
+func (a ApplicationDefined) Payload() (ApplicationPayload, error) {
+	applicationPayloadRegistry.RLock()
+	newPayload, ok := applicationPayloadRegistry.payloads[applicationPayloadKey{Name: a.Name, SubType: a.SubType}]
+	applicationPayloadRegistry.RUnlock()
+	if !ok {
+		return nil, errAppDefinedUnknownPayload
+	}
+
+	payload := newPayload()
+	if err := payload.Unmarshal(a.Data); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// isApplicationName reports whether name is made of four ASCII characters,
+// as required for the name of an ApplicationDefined packet.
+func isApplicationName(name string) bool {
+	if len(name) != 4 {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] > 0x7F {
+			return false
+		}
+	}
+
+	return true
+}
@@ -4,6 +4,7 @@
 package rtcp
 
 import (
+	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -246,3 +247,78 @@ func TestTApplicationPacketMarshal(t *testing.T) {
 		assert.Equalf(t, marshalSize, len(rawPacket), "MarshalSize %q", test.Name)
 	}
 }
+
+// pionTelemetry is an example of a typed application-defined payload.
+type pionTelemetry struct {
+	FramesDecoded uint32
+	FramesDropped uint16
+}
+
+func (p *pionTelemetry) Marshal() ([]byte, error) {
+	data := make([]byte, 6)
+	binary.BigEndian.PutUint32(data, p.FramesDecoded)
+	binary.BigEndian.PutUint16(data[4:], p.FramesDropped)
+
+	return data, nil
+}
+
+func (p *pionTelemetry) Unmarshal(data []byte) error {
+	if len(data) < 6 {
+		return errPacketTooShort
+	}
+	p.FramesDecoded = binary.BigEndian.Uint32(data)
+	p.FramesDropped = binary.BigEndian.Uint16(data[4:])
+
+	return nil
+}
+
+func TestApplicationPayloadRegistry(t *testing.T) {
+	newTelemetry := func() ApplicationPayload { return new(pionTelemetry) }
+
+	assert.NoError(t, RegisterApplicationPayload("PION", 1, newTelemetry))
+	defer UnregisterApplicationPayload("PION", 1)
+
+	assert.ErrorIs(t, RegisterApplicationPayload("PION", 1, newTelemetry), errAppDefinedPayloadRegistered)
+	assert.ErrorIs(t, RegisterApplicationPayload("PION!", 1, newTelemetry), errAppDefinedInvalidName)
+	assert.ErrorIs(t, RegisterApplicationPayload("PI\xffN", 1, newTelemetry), errAppDefinedInvalidName)
+	assert.ErrorIs(t, RegisterApplicationPayload("PION", 32, newTelemetry), errInvalidHeader)
+	assert.ErrorIs(t, RegisterApplicationPayload("PION", 2, nil), errNilApplicationPayloadConstructor)
+
+	expected := &pionTelemetry{FramesDecoded: 3000, FramesDropped: 7}
+	app, err := NewApplicationDefined(0x4baae1ab, "PION", 1, expected)
+	assert.NoError(t, err)
+
+	rawPacket, err := app.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		// Application Packet Type (SubType 1, padding) + Length(0x0004)
+		0xa1, 0xcc, 0x00, 0x04,
+		// sender=0x4baae1ab
+		0x4b, 0xaa, 0xe1, 0xab,
+		// name='PION'
+		0x50, 0x49, 0x4f, 0x4e,
+		// frames decoded, frames dropped
+		0x00, 0x00, 0x0b, 0xb8, 0x00, 0x07,
+		// padding
+		0x02, 0x02,
+	}, rawPacket)
+
+	packets, err := Unmarshal(rawPacket)
+	assert.NoError(t, err)
+	decoded, ok := packets[0].(*ApplicationDefined)
+	assert.True(t, ok)
+
+	payload, err := decoded.Payload()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, payload)
+
+	// Payloads are keyed by both name and subtype
+	decoded.SubType = 2
+	_, err = decoded.Payload()
+	assert.ErrorIs(t, err, errAppDefinedUnknownPayload)
+
+	decoded.SubType = 1
+	decoded.Data = decoded.Data[:4]
+	_, err = decoded.Payload()
+	assert.ErrorIs(t, err, errPacketTooShort)
+}
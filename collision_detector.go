@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"net"
+	"reflect"
+	"time"
+)
+
+const (
+	// Conflicting addresses are forgotten after this many RTCP intervals,
+	// see RFC 3550 section 8.2.
+	collisionConflictTimeout = 10
+	// Sources are forgotten after this many RTCP intervals without packets.
+	collisionSourceTimeout = 5
+
+	collisionGoodbyeReason = "SSRC collision"
+)
+
+// CollisionType is the type of a Collision.
+type CollisionType int
+
+// Collision types.
+const (
+	// SSRCCollision means that a local SSRC is used by another participant.
+	// The local source must change its SSRC with ChangeSSRC.
+	SSRCCollision CollisionType = iota + 1
+	// OwnTrafficLooped means that packets sent from a local SSRC were
+	// received back, from another transport address.
+	OwnTrafficLooped
+	// ThirdPartyCollision means that two other participants use the same
+	// SSRC.
+	ThirdPartyCollision
+	// ThirdPartyLoop means that packets from another participant were
+	// received from two transport addresses.
+	ThirdPartyLoop
+)
+
+// Collision reports an SSRC collision or a forwarding loop. The packets of
+// the compound packet that carry its SSRC should be discarded.
+type Collision struct {
+	Type CollisionType
+	SSRC uint32
+	// Address is the transport address the conflicting packet came from.
+	Address net.Addr
+	// CNAME carried by the conflicting packet, if any.
+	CNAME string
+}
+
+type collisionSource struct {
+	address  string
+	cname    string
+	lastSeen time.Time
+}
+
+// CollisionDetector detects SSRC collisions and forwarding loops from the
+// source transport address and CNAME of incoming compound RTCP packets,
+// using the algorithm described in RFC 3550 section 8.2.
+//
+// CollisionDetector is not safe for concurrent use.
+type CollisionDetector struct {
+	cname       string
+	local       map[uint32]bool
+	sources     map[uint32]*collisionSource
+	conflicting map[string]time.Time
+}
+
+// NewCollisionDetector returns a CollisionDetector for a participant with the
+// given CNAME, sending from the given SSRCs.
+func NewCollisionDetector(cname string, ssrcs ...uint32) *CollisionDetector {
+	detector := &CollisionDetector{
+		cname:       cname,
+		local:       map[uint32]bool{},
+		sources:     map[uint32]*collisionSource{},
+		conflicting: map[string]time.Time{},
+	}
+	for _, ssrc := range ssrcs {
+		detector.local[ssrc] = true
+	}
+
+	return detector
+}
+
+// AddLocalSSRC adds an SSRC used by the local participant.
+func (d *CollisionDetector) AddLocalSSRC(ssrc uint32) {
+	d.local[ssrc] = true
+}
+
+// RemoveLocalSSRC removes an SSRC that is no longer used by the local
+// participant.
+func (d *CollisionDetector) RemoveLocalSSRC(ssrc uint32) {
+	delete(d.local, ssrc)
+}
+
+// ChangeSSRC replaces a local SSRC after an SSRC collision, and returns the
+// Goodbye packet to send with the old SSRC. Packets still received with the
+// old SSRC from the conflicting participant are then handled as any other
+// source.
+func (d *CollisionDetector) ChangeSSRC(oldSSRC, newSSRC uint32) *Goodbye {
+	delete(d.local, oldSSRC)
+	d.local[newSSRC] = true
+
+	return &Goodbye{
+		Sources: []uint32{oldSSRC},
+		Reason:  collisionGoodbyeReason,
+	}
+}
+
+// Receive checks a compound packet received at the given time from the given
+// source transport address, and returns the collisions and loops it reveals.
+// The detection relies on the address: if it is unknown, that is nil or a
+// nil pointer, the packet is not checked.
+func (d *CollisionDetector) Receive(compound CompoundPacket, address net.Addr, now time.Time) []Collision {
+	if len(compound) == 0 || isNilAddr(address) {
+		return nil
+	}
+
+	ssrcs, cnames := compoundSources(compound)
+
+	var collisions []Collision
+	for _, ssrc := range ssrcs {
+		if collision, ok := d.check(ssrc, cnames[ssrc], address, now); ok {
+			collisions = append(collisions, collision)
+		}
+	}
+
+	return collisions
+}
+
+// Timeout forgets the sources that have been inactive for 5 RTCP intervals
+// and the conflicting addresses not seen for 10 intervals. It should be
+// called periodically with the current deterministic RTCP interval.
+func (d *CollisionDetector) Timeout(now time.Time, interval time.Duration) {
+	sourceDeadline := now.Add(-collisionSourceTimeout * interval)
+	for ssrc, source := range d.sources {
+		if source.lastSeen.Before(sourceDeadline) {
+			delete(d.sources, ssrc)
+		}
+	}
+
+	conflictDeadline := now.Add(-collisionConflictTimeout * interval)
+	for address, lastSeen := range d.conflicting {
+		if lastSeen.Before(conflictDeadline) {
+			delete(d.conflicting, address)
+		}
+	}
+}
+
+func (d *CollisionDetector) check(ssrc uint32, cname string, address net.Addr, now time.Time) (Collision, bool) {
+	key := address.String()
+
+	if d.local[ssrc] {
+		collision := Collision{SSRC: ssrc, Address: address, CNAME: cname}
+
+		// A collision or loop of our own packets
+		if _, ok := d.conflicting[key]; ok {
+			d.conflicting[key] = now
+			if cname == "" || cname == d.cname {
+				collision.Type = OwnTrafficLooped
+
+				return collision, true
+			}
+
+			return Collision{}, false
+		}
+
+		d.conflicting[key] = now
+		if cname == d.cname {
+			collision.Type = OwnTrafficLooped
+		} else {
+			collision.Type = SSRCCollision
+			// Keep track of the other participant in case we change our SSRC
+			d.sources[ssrc] = &collisionSource{address: key, cname: cname, lastSeen: now}
+		}
+
+		return collision, true
+	}
+
+	source, ok := d.sources[ssrc]
+	if !ok {
+		d.sources[ssrc] = &collisionSource{address: key, cname: cname, lastSeen: now}
+
+		return Collision{}, false
+	}
+
+	if source.address == key {
+		source.lastSeen = now
+		if cname != "" {
+			source.cname = cname
+		}
+
+		return Collision{}, false
+	}
+
+	collision := Collision{Type: ThirdPartyLoop, SSRC: ssrc, Address: address, CNAME: cname}
+	if cname != "" && source.cname != "" && cname != source.cname {
+		collision.Type = ThirdPartyCollision
+	}
+
+	return collision, true
+}
+
+func isNilAddr(address net.Addr) bool {
+	if address == nil {
+		return true
+	}
+	value := reflect.ValueOf(address)
+
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
+
+// compoundSources returns the SSRCs found in a compound packet, in order of
+// appearance, and the CNAMEs they are described with.
+func compoundSources(compound CompoundPacket) ([]uint32, map[uint32]string) {
+	var ssrcs []uint32
+	seen := map[uint32]bool{}
+	add := func(ssrc uint32) {
+		if !seen[ssrc] {
+			seen[ssrc] = true
+			ssrcs = append(ssrcs, ssrc)
+		}
+	}
+
+	cnames := map[uint32]string{}
+	for _, packet := range compound {
+		if sdes, ok := packet.(*SourceDescription); ok {
+			for _, chunk := range sdes.Chunks {
+				add(chunk.Source)
+				for _, item := range chunk.Items {
+					if item.Type == SDESCNAME {
+						cnames[chunk.Source] = item.Text
+					}
+				}
+			}
+
+			continue
+		}
+
+		if ssrc, ok := packetSenderSSRC(packet); ok {
+			add(ssrc)
+		}
+	}
+
+	// The CNAME of the compound packet describes the source that sent it
+	if ssrc, ok := packetSenderSSRC(compound[0]); ok {
+		if cname, _ := compound.CNAME(); cname != "" && cnames[ssrc] == "" {
+			cnames[ssrc] = cname
+		}
+	}
+
+	return ssrcs, cnames
+}
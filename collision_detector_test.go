@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollisionDetectorSSRCCollision(t *testing.T) {
+	now := time.Unix(1000, 0)
+	detector := NewCollisionDetector("local", 1)
+	remote := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 5000}
+
+	collisions := detector.Receive(CompoundPacket{
+		&ReceiverReport{SSRC: 1},
+		NewCNAMESourceDescription(1, "remote"),
+	}, remote, now)
+	assert.Equal(t, []Collision{{Type: SSRCCollision, SSRC: 1, Address: remote, CNAME: "remote"}}, collisions)
+
+	bye := detector.ChangeSSRC(1, 2)
+	assert.Equal(t, &Goodbye{Sources: []uint32{1}, Reason: "SSRC collision"}, bye)
+
+	// The other participant keeps its SSRC
+	collisions = detector.Receive(CompoundPacket{
+		&ReceiverReport{SSRC: 1},
+		NewCNAMESourceDescription(1, "remote"),
+	}, remote, now)
+	assert.Empty(t, collisions)
+}
+
+func TestCollisionDetectorOwnTrafficLooped(t *testing.T) {
+	now := time.Unix(1000, 0)
+	detector := NewCollisionDetector("local", 1)
+	looped := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 3), Port: 5000}
+
+	collisions := detector.Receive(CompoundPacket{
+		&SenderReport{SSRC: 1},
+		NewCNAMESourceDescription(1, "local"),
+	}, looped, now)
+	assert.Equal(t, []Collision{{Type: OwnTrafficLooped, SSRC: 1, Address: looped, CNAME: "local"}}, collisions)
+
+	// Without CNAME, packets from a known conflicting address are loops
+	collisions = detector.Receive(CompoundPacket{
+		&TransportLayerNack{SenderSSRC: 1, MediaSSRC: 5},
+	}, looped, now)
+	assert.Equal(t, []Collision{{Type: OwnTrafficLooped, SSRC: 1, Address: looped}}, collisions)
+
+	// Conflicting addresses time out
+	detector.Timeout(now.Add(51*time.Second), 5*time.Second)
+	collisions = detector.Receive(CompoundPacket{
+		&TransportLayerNack{SenderSSRC: 1, MediaSSRC: 5},
+	}, looped, now.Add(51*time.Second))
+	assert.Equal(t, []Collision{{Type: SSRCCollision, SSRC: 1, Address: looped}}, collisions)
+}
+
+func TestCollisionDetectorThirdParty(t *testing.T) {
+	now := time.Unix(1000, 0)
+	detector := NewCollisionDetector("local", 1)
+	first := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 5000}
+	second := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 4), Port: 5000}
+
+	compound := CompoundPacket{
+		&ReceiverReport{SSRC: 7},
+		&SourceDescription{Chunks: []SourceDescriptionChunk{
+			{Source: 7, Items: []SourceDescriptionItem{{Type: SDESCNAME, Text: "alice"}}},
+			{Source: 8, Items: []SourceDescriptionItem{{Type: SDESCNAME, Text: "carol"}}},
+		}},
+		&PictureLossIndication{SenderSSRC: 7, MediaSSRC: 1},
+	}
+	assert.Empty(t, detector.Receive(compound, first, now))
+	assert.Empty(t, detector.Receive(compound, first, now))
+
+	// Same source forwarded from another address
+	collisions := detector.Receive(CompoundPacket{&ReceiverReport{SSRC: 7}}, second, now)
+	assert.Equal(t, []Collision{{Type: ThirdPartyLoop, SSRC: 7, Address: second}}, collisions)
+
+	// Another participant using the same SSRC
+	collisions = detector.Receive(CompoundPacket{
+		&ReceiverReport{SSRC: 7},
+		NewCNAMESourceDescription(7, "bob"),
+	}, second, now)
+	assert.Equal(t, []Collision{{Type: ThirdPartyCollision, SSRC: 7, Address: second, CNAME: "bob"}}, collisions)
+
+	// Sources time out
+	later := now.Add(26 * time.Second)
+	detector.Timeout(later, 5*time.Second)
+	assert.Empty(t, detector.Receive(CompoundPacket{&ReceiverReport{SSRC: 7}}, second, later))
+	assert.Empty(t, detector.Receive(nil, second, later))
+}
+
+func TestCollisionDetectorUnknownAddress(t *testing.T) {
+	now := time.Unix(1000, 0)
+	detector := NewCollisionDetector("local", 1)
+	compound := CompoundPacket{&ReceiverReport{SSRC: 1}, NewCNAMESourceDescription(1, "remote")}
+
+	assert.Empty(t, detector.Receive(compound, nil, now))
+	var address *net.UDPAddr
+	assert.Empty(t, detector.Receive(compound, address, now))
+}
@@ -0,0 +1,375 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+)
+
+const (
+	nackPairLength = 4
+	// Largest number of NACK pairs that fit in the header length field.
+	nackMaxPairs = math.MaxUint8 - tlnLength
+)
+
+// CompoundPacketBuilder assembles the RTCP packets of the local participant
+// into compound packets that are valid according to RFC 3550 section 6.1
+// and each fit within a size budget, such as the path MTU minus the lower
+// layer headers.
+//
+// Every compound packet begins with a report and carries the CNAME of the
+// local source. Reception reports are prioritized: they are spread over as
+// many ReceiverReport packets and compound packets as needed, 31 per
+// report. Additional SDES items are then included in the first compound
+// packet with room for them. Finally, feedback packets are appended in
+// order, TransportLayerNack and TransportLayerCC packets being split when
+// they don't fit. The parts of a split TransportLayerCC packet after the
+// first use the following FbPktCount values.
+type CompoundPacketBuilder struct {
+	// MaxSize is the maximum size in octets of each compound packet.
+	MaxSize int
+
+	// Report is the SenderReport or ReceiverReport of the local source,
+	// with any number of reception reports.
+	Report Packet
+
+	// CNAME of the local source.
+	CNAME string
+
+	// Items are additional SDES items describing the local source.
+	Items []SourceDescriptionItem
+
+	// Feedback packets to send, in order.
+	Feedback []Packet
+}
+
+type compoundDatagram struct {
+	reports  []Packet
+	sdes     *SourceDescription
+	feedback []Packet
+	size     int
+}
+
+func (d *compoundDatagram) packets() CompoundPacket {
+	packets := make(CompoundPacket, 0, len(d.reports)+1+len(d.feedback))
+	packets = append(packets, d.reports...)
+	packets = append(packets, d.sdes)
+
+	return append(packets, d.feedback...)
+}
+
+// Build returns the compound packets, in the order they should be sent.
+func (b CompoundPacketBuilder) Build() ([]CompoundPacket, error) {
+	reportPackets, err := SplitReports(b.Report)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([][]ReceptionReport, len(reportPackets))
+	for i, packet := range reportPackets {
+		reports[i] = takeReports(packet)
+	}
+	first := reportPackets[0]
+	ssrc, _ := packetSenderSSRC(first)
+
+	if b.CNAME == "" {
+		return nil, errMissingCNAME
+	}
+	minimal := NewCNAMESourceDescription(ssrc, b.CNAME)
+	freshSize := headerLength + ssrcLength + minimal.MarshalSize()
+	if first.MarshalSize()+minimal.MarshalSize() > b.MaxSize || freshSize > b.MaxSize {
+		return nil, errCompoundSizeTooSmall
+	}
+
+	newDatagram := func(report Packet) *compoundDatagram {
+		return &compoundDatagram{
+			reports: []Packet{report},
+			sdes:    minimal,
+			size:    report.MarshalSize() + minimal.MarshalSize(),
+		}
+	}
+	datagrams := []*compoundDatagram{newDatagram(first)}
+
+	// Reception reports, in the report packets returned by SplitReports,
+	// which are split further when they don't fit
+	for i, packet := range reportPackets {
+		current := datagrams[len(datagrams)-1]
+		if i > 0 {
+			if b.MaxSize-current.size >= headerLength+ssrcLength+receptionReportLength {
+				current.reports = append(current.reports, packet)
+				current.size += headerLength + ssrcLength
+			} else {
+				current = newDatagram(packet)
+				datagrams = append(datagrams, current)
+			}
+		}
+
+		for _, report := range reports[i] {
+			if b.MaxSize-current.size < receptionReportLength {
+				current = newDatagram(&ReceiverReport{SSRC: ssrc})
+				datagrams = append(datagrams, current)
+			}
+			appendReport(current.reports[len(current.reports)-1], report)
+			current.size += receptionReportLength
+		}
+	}
+
+	if len(b.Items) > 0 {
+		if datagrams, err = b.placeItems(datagrams, ssrc, minimal, newDatagram); err != nil {
+			return nil, err
+		}
+	}
+
+	// Feedback, split as needed
+	freshRoom := b.MaxSize - freshSize
+	current := datagrams[len(datagrams)-1]
+	for _, feedback := range b.Feedback {
+		for feedback != nil {
+			room := b.MaxSize - current.size
+			if size := feedback.MarshalSize(); size <= room && !nackTooLong(feedback) {
+				current.feedback = append(current.feedback, feedback)
+				current.size += size
+
+				break
+			}
+
+			head, rest := splitFeedback(feedback, room)
+			if head != nil {
+				current.feedback = append(current.feedback, head)
+				current.size += head.MarshalSize()
+				if feedback = rest; feedback == nil {
+					break
+				}
+			} else if room >= freshRoom {
+				return nil, errFeedbackTooLarge
+			}
+
+			current = newDatagram(&ReceiverReport{SSRC: ssrc})
+			datagrams = append(datagrams, current)
+		}
+	}
+
+	compounds := make([]CompoundPacket, 0, len(datagrams))
+	for _, datagram := range datagrams {
+		compounds = append(compounds, datagram.packets())
+	}
+
+	return compounds, nil
+}
+
+// placeItems replaces the minimal SDES of the first compound packet with room
+// for the additional items, adding a compound packet if none has.
+func (b CompoundPacketBuilder) placeItems(
+	datagrams []*compoundDatagram,
+	ssrc uint32,
+	minimal *SourceDescription,
+	newDatagram func(Packet) *compoundDatagram,
+) ([]*compoundDatagram, error) {
+	items := append([]SourceDescriptionItem{{Type: SDESCNAME, Text: b.CNAME}}, b.Items...)
+	full := &SourceDescription{Chunks: []SourceDescriptionChunk{{Source: ssrc, Items: items}}}
+	extra := full.MarshalSize() - minimal.MarshalSize()
+
+	for _, datagram := range datagrams {
+		if datagram.size+extra <= b.MaxSize {
+			datagram.sdes = full
+			datagram.size += extra
+
+			return datagrams, nil
+		}
+	}
+
+	datagram := newDatagram(&ReceiverReport{SSRC: ssrc})
+	if datagram.size+extra > b.MaxSize {
+		return nil, errCompoundSizeTooSmall
+	}
+	datagram.sdes = full
+	datagram.size += extra
+
+	return append(datagrams, datagram), nil
+}
+
+// takeReports removes the reception reports of a SenderReport or
+// ReceiverReport and returns them.
+func takeReports(packet Packet) []ReceptionReport {
+	var reports []ReceptionReport
+	switch packet := packet.(type) {
+	case *SenderReport:
+		reports, packet.Reports = packet.Reports, nil
+	case *ReceiverReport:
+		reports, packet.Reports = packet.Reports, nil
+	}
+
+	return reports
+}
+
+func appendReport(packet Packet, report ReceptionReport) {
+	switch packet := packet.(type) {
+	case *SenderReport:
+		packet.Reports = append(packet.Reports, report)
+	case *ReceiverReport:
+		packet.Reports = append(packet.Reports, report)
+	}
+}
+
+func nackTooLong(packet Packet) bool {
+	nack, ok := packet.(*TransportLayerNack)
+
+	return ok && len(nack.Nacks) > nackMaxPairs
+}
+
+// splitFeedback splits a feedback packet into a first part that fits in the
+// given size and the rest, if any. It returns a nil head if the packet can't
+// be split to fit.
+func splitFeedback(packet Packet, size int) (Packet, Packet) {
+	switch packet := packet.(type) {
+	case *TransportLayerNack:
+		head, rest := splitTransportLayerNack(packet, size)
+		switch {
+		case head == nil:
+			return nil, nil
+		case rest == nil:
+			return head, nil
+		default:
+			return head, rest
+		}
+	case *TransportLayerCC:
+		head, rest := splitTransportLayerCC(packet, size)
+		switch {
+		case head == nil:
+			return nil, nil
+		case rest == nil:
+			return head, nil
+		default:
+			return head, rest
+		}
+	default:
+		return nil, nil
+	}
+}
+
+func splitTransportLayerNack(nack *TransportLayerNack, size int) (*TransportLayerNack, *TransportLayerNack) {
+	count := min((size-headerLength-nackOffset)/nackPairLength, nackMaxPairs, len(nack.Nacks))
+	if count <= 0 {
+		return nil, nil
+	}
+
+	head := &TransportLayerNack{SenderSSRC: nack.SenderSSRC, MediaSSRC: nack.MediaSSRC, Nacks: nack.Nacks[:count]}
+	if count == len(nack.Nacks) {
+		return head, nil
+	}
+
+	return head, &TransportLayerNack{SenderSSRC: nack.SenderSSRC, MediaSSRC: nack.MediaSSRC, Nacks: nack.Nacks[count:]}
+}
+
+// splitTransportLayerCC splits a TransportLayerCC packet at a chunk boundary,
+// or within a run length chunk, so that the first part fits in the given
+// size. The rest starts with a reference time and first delta computed from
+// the arrival time of its first received packet.
+//
+//nolint:cyclop
+func splitTransportLayerCC(cc *TransportLayerCC, size int) (*TransportLayerCC, *TransportLayerCC) {
+	head := &TransportLayerCC{
+		SenderSSRC:         cc.SenderSSRC,
+		MediaSSRC:          cc.MediaSSRC,
+		BaseSequenceNumber: cc.BaseSequenceNumber,
+		ReferenceTime:      cc.ReferenceTime,
+		FbPktCount:         cc.FbPktCount,
+	}
+	length := headerLength + packetChunkOffset
+	fits := func(extra int) bool {
+		return length+extra+getPadding(length+extra) <= size
+	}
+
+	remaining := int(cc.PacketStatusCount)
+	deltas := cc.RecvDeltas
+	arrival := int64(cc.ReferenceTime) * tccReferenceTimeUnit
+
+	var restChunks []PacketStatusChunk
+	for i, chunk := range cc.PacketChunks {
+		if remaining == 0 {
+			break
+		}
+
+		symbols := tccChunkSymbols(chunk, remaining)
+		count := len(symbols)
+		if cost := packetStatusChunkLength + tccDeltaLength(symbols); !fits(cost) {
+			runLength, ok := tccRunLength(chunk)
+			if !ok {
+				restChunks = cc.PacketChunks[i:]
+
+				break
+			}
+
+			// Take as much of the run as fits
+			count = 0
+			for count < len(symbols) && fits(packetStatusChunkLength+tccDeltaLength(symbols[:count+1])) {
+				count++
+			}
+			if count == 0 {
+				restChunks = cc.PacketChunks[i:]
+
+				break
+			}
+			chunk = &RunLengthChunk{
+				PacketStatusSymbol: runLength.PacketStatusSymbol,
+				RunLength:          uint16(count), //nolint:gosec // G115
+			}
+			restChunks = append([]PacketStatusChunk{&RunLengthChunk{
+				PacketStatusSymbol: runLength.PacketStatusSymbol,
+				RunLength:          runLength.RunLength - uint16(count), //nolint:gosec // G115
+			}}, cc.PacketChunks[i+1:]...)
+		}
+
+		for _, symbol := range symbols[:count] {
+			if tccSymbolHasDelta(symbol) {
+				if len(deltas) == 0 {
+					return nil, nil
+				}
+				head.RecvDeltas = append(head.RecvDeltas, deltas[0])
+				arrival += deltas[0].Delta
+				deltas = deltas[1:]
+			}
+		}
+		head.PacketChunks = append(head.PacketChunks, chunk)
+		head.PacketStatusCount += uint16(count) //nolint:gosec // G115
+		length += packetStatusChunkLength + tccDeltaLength(symbols[:count])
+		remaining -= count
+
+		if restChunks != nil {
+			break
+		}
+	}
+
+	if head.PacketStatusCount == 0 {
+		return nil, nil
+	}
+	setTransportLayerCCHeader(head)
+	if remaining == 0 {
+		return head, nil
+	}
+
+	rest := &TransportLayerCC{
+		SenderSSRC:         cc.SenderSSRC,
+		MediaSSRC:          cc.MediaSSRC,
+		BaseSequenceNumber: cc.BaseSequenceNumber + head.PacketStatusCount,
+		PacketStatusCount:  uint16(remaining), //nolint:gosec // G115
+		ReferenceTime:      cc.ReferenceTime,
+		FbPktCount:         cc.FbPktCount + 1,
+		PacketChunks:       restChunks,
+		RecvDeltas:         make([]*RecvDelta, 0, len(deltas)),
+	}
+	if len(deltas) > 0 {
+		// Deltas are relative to the previous packet, except the first one
+		first := arrival + deltas[0].Delta
+		reference := floorDiv(first, tccReferenceTimeUnit)
+		rest.ReferenceTime = uint32(reference) & tccReferenceTimeMask //nolint:gosec // G115
+		rest.RecvDeltas = append(rest.RecvDeltas, &RecvDelta{
+			Type:  deltas[0].Type,
+			Delta: first - reference*tccReferenceTimeUnit,
+		})
+		rest.RecvDeltas = append(rest.RecvDeltas, deltas[1:]...)
+	}
+	setTransportLayerCCHeader(rest)
+
+	return head, rest
+}
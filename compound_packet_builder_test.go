@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Checks that compound packets are valid, fit in maxSize and survive a round
+// trip, and returns their packets.
+func checkCompoundPackets(t *testing.T, compounds []CompoundPacket, maxSize int) []Packet {
+	t.Helper()
+
+	var packets []Packet
+	for _, compound := range compounds {
+		assert.NoError(t, compound.Validate())
+		raw, err := compound.Marshal()
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(raw), maxSize)
+		assert.Equal(t, compound.MarshalSize(), len(raw))
+
+		var decoded CompoundPacket
+		assert.NoError(t, decoded.Unmarshal(raw))
+		assert.Len(t, decoded, len(compound))
+
+		packets = append(packets, compound...)
+	}
+
+	return packets
+}
+
+func TestCompoundPacketBuilderSingle(t *testing.T) {
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	compounds, err := CompoundPacketBuilder{
+		MaxSize:  1200,
+		Report:   &ReceiverReport{SSRC: 1, Reports: []ReceptionReport{{SSRC: 2}, {SSRC: 3}}},
+		CNAME:    "user@example.com",
+		Items:    []SourceDescriptionItem{{Type: SDESName, Text: "User"}},
+		Feedback: []Packet{pli},
+	}.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, []CompoundPacket{{
+		&ReceiverReport{SSRC: 1, Reports: []ReceptionReport{{SSRC: 2}, {SSRC: 3}}},
+		&SourceDescription{Chunks: []SourceDescriptionChunk{{
+			Source: 1,
+			Items: []SourceDescriptionItem{
+				{Type: SDESCNAME, Text: "user@example.com"},
+				{Type: SDESName, Text: "User"},
+			},
+		}}},
+		pli,
+	}}, compounds)
+	checkCompoundPackets(t, compounds, 1200)
+}
+
+func TestCompoundPacketBuilderReports(t *testing.T) {
+	reports := make([]ReceptionReport, 70)
+	for i := range reports {
+		reports[i].SSRC = uint32(i + 10) //nolint:gosec // G115
+	}
+
+	compounds, err := CompoundPacketBuilder{
+		MaxSize: 1200,
+		Report:  &SenderReport{SSRC: 1, NTPTime: 0xda8bd1fcdddda05a, Reports: reports},
+		CNAME:   "user@example.com",
+		Items:   []SourceDescriptionItem{{Type: SDESTool, Text: string(make([]byte, 200))}},
+	}.Build()
+	assert.NoError(t, err)
+	assert.Len(t, compounds, 2)
+	packets := checkCompoundPackets(t, compounds, 1200)
+
+	sr, ok := compounds[0][0].(*SenderReport)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0xda8bd1fcdddda05a), sr.NTPTime)
+	_, ok = compounds[1][0].(*ReceiverReport)
+	assert.True(t, ok)
+
+	// All reports are sent in order, 31 at most per report packet
+	var sent []ReceptionReport
+	for _, packet := range packets {
+		switch packet := packet.(type) {
+		case *SenderReport:
+			assert.LessOrEqual(t, len(packet.Reports), countMax)
+			sent = append(sent, packet.Reports...)
+		case *ReceiverReport:
+			assert.LessOrEqual(t, len(packet.Reports), countMax)
+			assert.Equal(t, uint32(1), packet.SSRC)
+			sent = append(sent, packet.Reports...)
+		}
+	}
+	assert.Equal(t, reports, sent)
+
+	// Additional items are sent where they fit
+	sdes, ok := compounds[1][len(compounds[1])-1].(*SourceDescription)
+	assert.True(t, ok)
+	assert.Len(t, sdes.Chunks[0].Items, 2)
+}
+
+func TestCompoundPacketBuilderNack(t *testing.T) {
+	sequenceNumbers := make([]uint16, 0, 100)
+	for i := uint16(0); i < 100; i++ {
+		sequenceNumbers = append(sequenceNumbers, i*20)
+	}
+	nack := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers(sequenceNumbers)}
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+
+	compounds, err := CompoundPacketBuilder{
+		MaxSize:  200,
+		Report:   &ReceiverReport{SSRC: 1},
+		CNAME:    "user@example.com",
+		Feedback: []Packet{pli, nack},
+	}.Build()
+	assert.NoError(t, err)
+	assert.Greater(t, len(compounds), 2)
+
+	var pairs []NackPair
+	for _, packet := range checkCompoundPackets(t, compounds, 200) {
+		if nack, ok := packet.(*TransportLayerNack); ok {
+			assert.Equal(t, uint32(2), nack.MediaSSRC)
+			pairs = append(pairs, nack.Nacks...)
+		}
+	}
+	assert.Equal(t, nack.Nacks, pairs)
+	assert.Equal(t, pli, compounds[0][2])
+}
+
+// Returns the arrival time in microseconds of the received packets described
+// by a TransportLayerCC packet, by sequence number.
+func tccArrivals(t *testing.T, cc *TransportLayerCC) map[uint16]int64 {
+	t.Helper()
+
+	arrivals := map[uint16]int64{}
+	arrival := int64(cc.ReferenceTime) * tccReferenceTimeUnit
+	seq := cc.BaseSequenceNumber
+	remaining := int(cc.PacketStatusCount)
+	deltas := cc.RecvDeltas
+	for _, chunk := range cc.PacketChunks {
+		symbols := tccChunkSymbols(chunk, remaining)
+		for _, symbol := range symbols {
+			if tccSymbolHasDelta(symbol) {
+				arrival += deltas[0].Delta
+				deltas = deltas[1:]
+				arrivals[seq] = arrival
+			}
+			seq++
+		}
+		remaining -= len(symbols)
+	}
+	assert.Empty(t, deltas)
+	assert.Equal(t, 0, remaining)
+
+	return arrivals
+}
+
+func TestCompoundPacketBuilderTransportLayerCC(t *testing.T) {
+	cc := &TransportLayerCC{
+		SenderSSRC:         1,
+		MediaSSRC:          2,
+		BaseSequenceNumber: 65500,
+		ReferenceTime:      10,
+		FbPktCount:         3,
+		PacketChunks: []PacketStatusChunk{
+			&RunLengthChunk{PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta, RunLength: 120},
+			&RunLengthChunk{PacketStatusSymbol: TypeTCCPacketNotReceived, RunLength: 5},
+			&StatusVectorChunk{
+				SymbolSize: TypeTCCSymbolSizeTwoBit,
+				SymbolList: []uint16{
+					TypeTCCPacketReceivedSmallDelta, TypeTCCPacketReceivedLargeDelta, TypeTCCPacketNotReceived,
+					TypeTCCPacketReceivedSmallDelta, TypeTCCPacketReceivedSmallDelta, TypeTCCPacketNotReceived,
+					TypeTCCPacketReceivedLargeDelta,
+				},
+			},
+			&RunLengthChunk{PacketStatusSymbol: TypeTCCPacketReceivedLargeDelta, RunLength: 60},
+		},
+	}
+	for i := 0; i < 120; i++ {
+		cc.RecvDeltas = append(cc.RecvDeltas, &RecvDelta{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1250})
+	}
+	cc.RecvDeltas = append(cc.RecvDeltas,
+		&RecvDelta{Type: TypeTCCPacketReceivedSmallDelta, Delta: 500},
+		&RecvDelta{Type: TypeTCCPacketReceivedLargeDelta, Delta: -2000},
+		&RecvDelta{Type: TypeTCCPacketReceivedSmallDelta, Delta: 63750},
+		&RecvDelta{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+		&RecvDelta{Type: TypeTCCPacketReceivedLargeDelta, Delta: 100000},
+	)
+	for i := 0; i < 60; i++ {
+		cc.RecvDeltas = append(cc.RecvDeltas, &RecvDelta{Type: TypeTCCPacketReceivedLargeDelta, Delta: -1000})
+	}
+	cc.PacketStatusCount = 120 + 5 + 7 + 60
+	setTransportLayerCCHeader(cc)
+	expected := tccArrivals(t, cc)
+
+	compounds, err := CompoundPacketBuilder{
+		MaxSize:  120,
+		Report:   &ReceiverReport{SSRC: 1},
+		CNAME:    "user@example.com",
+		Feedback: []Packet{cc},
+	}.Build()
+	assert.NoError(t, err)
+	assert.Greater(t, len(compounds), 2)
+
+	arrivals := map[uint16]int64{}
+	statuses := 0
+	fbPktCount := uint8(3)
+	nextSequenceNumber := uint16(65500)
+	for _, packet := range checkCompoundPackets(t, compounds, 120) {
+		part, ok := packet.(*TransportLayerCC)
+		if !ok {
+			continue
+		}
+		assert.Equal(t, fbPktCount, part.FbPktCount)
+		assert.Equal(t, nextSequenceNumber, part.BaseSequenceNumber)
+		fbPktCount++
+		nextSequenceNumber += part.PacketStatusCount
+		statuses += int(part.PacketStatusCount)
+
+		// Parts are valid on their own
+		raw, err := part.Marshal()
+		assert.NoError(t, err)
+		var decoded TransportLayerCC
+		assert.NoError(t, decoded.Unmarshal(raw))
+		assert.Equal(t, part.RecvDeltas, decoded.RecvDeltas)
+
+		for seq, arrival := range tccArrivals(t, part) {
+			arrivals[seq] = arrival
+		}
+	}
+	assert.Equal(t, 192, statuses)
+	assert.Equal(t, expected, arrivals)
+}
+
+func TestCompoundPacketBuilderErrors(t *testing.T) {
+	for name, test := range map[string]struct {
+		builder CompoundPacketBuilder
+		err     error
+	}{
+		"bad report": {
+			builder: CompoundPacketBuilder{MaxSize: 1200, Report: &Goodbye{}, CNAME: "cname"},
+			err:     errBadFirstPacket,
+		},
+		"missing CNAME": {
+			builder: CompoundPacketBuilder{MaxSize: 1200, Report: &ReceiverReport{}},
+			err:     errMissingCNAME,
+		},
+		"too small": {
+			builder: CompoundPacketBuilder{MaxSize: 20, Report: &ReceiverReport{}, CNAME: "cname"},
+			err:     errCompoundSizeTooSmall,
+		},
+		"items too large": {
+			builder: CompoundPacketBuilder{
+				MaxSize: 100,
+				Report:  &ReceiverReport{},
+				CNAME:   "cname",
+				Items:   []SourceDescriptionItem{{Type: SDESNote, Text: string(make([]byte, 100))}},
+			},
+			err: errCompoundSizeTooSmall,
+		},
+		"feedback too large": {
+			builder: CompoundPacketBuilder{
+				MaxSize:  100,
+				Report:   &ReceiverReport{},
+				CNAME:    "cname",
+				Feedback: []Packet{&ApplicationDefined{Name: "NAME", Data: make([]byte, 100)}},
+			},
+			err: errFeedbackTooLarge,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.builder.Build()
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
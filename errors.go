@@ -6,37 +6,53 @@ package rtcp
 import "errors"
 
 var (
-	errWrongMarshalSize         = errors.New("rtcp: wrong marshal size")
-	errInvalidTotalLost         = errors.New("rtcp: invalid total lost count")
-	errInvalidHeader            = errors.New("rtcp: invalid header")
-	errEmptyCompound            = errors.New("rtcp: empty compound packet")
-	errBadFirstPacket           = errors.New("rtcp: first packet in compound must be SR or RR")
-	errMissingCNAME             = errors.New("rtcp: compound missing SourceDescription with CNAME")
-	errPacketBeforeCNAME        = errors.New("rtcp: feedback packet seen before CNAME")
-	errTooManySSRCs             = errors.New("rtcp: too many SSRCs")
-	errTooManyReports           = errors.New("rtcp: too many reports")
-	errTooManyChunks            = errors.New("rtcp: too many chunks")
-	errTooManySources           = errors.New("rtcp: too many sources")
-	errPacketTooShort           = errors.New("rtcp: packet too short")
-	errWrongType                = errors.New("rtcp: wrong packet type")
-	errSDESTextTooLong          = errors.New("rtcp: sdes must be < 255 octets long")
-	errSDESMissingType          = errors.New("rtcp: sdes item missing type")
-	errReasonTooLong            = errors.New("rtcp: reason must be < 255 octets long")
-	errBadVersion               = errors.New("rtcp: invalid packet version")
-	errBadLength                = errors.New("rtcp: invalid packet length")
-	errWrongPadding             = errors.New("rtcp: invalid padding value")
-	errWrongFeedbackType        = errors.New("rtcp: wrong feedback message type")
-	errWrongPayloadType         = errors.New("rtcp: wrong payload type")
-	errHeaderTooSmall           = errors.New("rtcp: header length is too small")
-	errSSRCMustBeZero           = errors.New("rtcp: media SSRC must be 0")
-	errMissingREMBidentifier    = errors.New("missing REMB identifier")
-	errSSRCNumAndLengthMismatch = errors.New("SSRC num and length do not match")
-	errInvalidSizeOrStartIndex  = errors.New("invalid size or startIndex")
-	errInvalidBitrate           = errors.New("invalid bitrate")
-	errWrongChunkType           = errors.New("rtcp: wrong chunk type")
-	errBadStructMemberType      = errors.New("rtcp: struct contains unexpected member type")
-	errBadReadParameter         = errors.New("rtcp: cannot read into non-pointer")
-	errAppDefinedInvalidLength  = errors.New("rtcp: application defined type invalid length")
-	errAppDefinedDataTooLarge   = errors.New("rtcp: application defined data is too large")
-	errAppDefinedInvalidName    = errors.New("rtcp: application defined name must be 4 ASCII chars")
+	errWrongMarshalSize                 = errors.New("rtcp: wrong marshal size")
+	errInvalidTotalLost                 = errors.New("rtcp: invalid total lost count")
+	errInvalidHeader                    = errors.New("rtcp: invalid header")
+	errEmptyCompound                    = errors.New("rtcp: empty compound packet")
+	errBadFirstPacket                   = errors.New("rtcp: first packet in compound must be SR or RR")
+	errMissingCNAME                     = errors.New("rtcp: compound missing SourceDescription with CNAME")
+	errPacketBeforeCNAME                = errors.New("rtcp: feedback packet seen before CNAME")
+	errTooManySSRCs                     = errors.New("rtcp: too many SSRCs")
+	errTooManyReports                   = errors.New("rtcp: too many reports")
+	errTooManyChunks                    = errors.New("rtcp: too many chunks")
+	errTooManySources                   = errors.New("rtcp: too many sources")
+	errPacketTooShort                   = errors.New("rtcp: packet too short")
+	errWrongType                        = errors.New("rtcp: wrong packet type")
+	errSDESTextTooLong                  = errors.New("rtcp: sdes must be < 255 octets long")
+	errSDESMissingType                  = errors.New("rtcp: sdes item missing type")
+	errReasonTooLong                    = errors.New("rtcp: reason must be < 255 octets long")
+	errBadVersion                       = errors.New("rtcp: invalid packet version")
+	errBadLength                        = errors.New("rtcp: invalid packet length")
+	errWrongPadding                     = errors.New("rtcp: invalid padding value")
+	errWrongFeedbackType                = errors.New("rtcp: wrong feedback message type")
+	errWrongPayloadType                 = errors.New("rtcp: wrong payload type")
+	errHeaderTooSmall                   = errors.New("rtcp: header length is too small")
+	errSSRCMustBeZero                   = errors.New("rtcp: media SSRC must be 0")
+	errMissingREMBidentifier            = errors.New("missing REMB identifier")
+	errMissingLNTFIdentifier            = errors.New("rtcp: missing LNTF identifier")
+	errInvalidLossNotificationDelta     = errors.New("rtcp: last received sequence number too far ahead of last decoded")
+	errSSRCNumAndLengthMismatch         = errors.New("SSRC num and length do not match")
+	errInvalidSizeOrStartIndex          = errors.New("invalid size or startIndex")
+	errInvalidBitrate                   = errors.New("invalid bitrate")
+	errWrongChunkType                   = errors.New("rtcp: wrong chunk type")
+	errBadStructMemberType              = errors.New("rtcp: struct contains unexpected member type")
+	errBadReadParameter                 = errors.New("rtcp: cannot read into non-pointer")
+	errAppDefinedInvalidLength          = errors.New("rtcp: application defined type invalid length")
+	errAppDefinedDataTooLarge           = errors.New("rtcp: application defined data is too large")
+	errAppDefinedInvalidName            = errors.New("rtcp: application defined name must be 4 ASCII chars")
+	errAppDefinedPayloadRegistered      = errors.New("rtcp: application defined payload is already registered")
+	errAppDefinedUnknownPayload         = errors.New("rtcp: application defined payload is not registered")
+	errNilApplicationPayloadConstructor = errors.New("rtcp: application defined payload constructor must not be nil")
+	errPacketTypeRegistered             = errors.New("rtcp: packet type is already registered")
+	errNilPacketConstructor             = errors.New("rtcp: packet constructor must not be nil")
+	errFeedbackIdentifierRequired       = errors.New("rtcp: application layer feedback must be registered by identifier")
+	errReportBlockTypeRegistered        = errors.New("rtcp: report block type is already registered")
+	errNilReportBlockConstructor        = errors.New("rtcp: report block constructor must not be nil")
+	errCompoundSizeTooSmall             = errors.New("rtcp: compound packet size limit is too small")
+	errFeedbackTooLarge                 = errors.New("rtcp: feedback packet does not fit in compound packet size limit")
+	errTCCSizeTooSmall                  = errors.New("rtcp: size limit is too small for transport layer cc")
+	errCCFBSizeTooSmall                 = errors.New("rtcp: size limit is too small for congestion control feedback")
+	errSSRCRewriteUnsupported           = errors.New("rtcp: SSRCs of type can't be rewritten")
+	errTCCDeltasMismatch                = errors.New("rtcp: transport layer cc deltas do not match packet statuses")
 )
@@ -5,6 +5,7 @@ package rtcp
 
 import (
 	"fmt"
+	"sync"
 )
 
 // The ExtendedReport packet is an Implementation of RTCP Extended
@@ -31,10 +32,29 @@ type ExtendedReport struct {
 
 // ReportBlock represents a single report within an ExtendedReport
 // packet.
+//
+// Report blocks are encoded and decoded by reflection: the block must be
+// a pointer to a struct whose first field is an XRHeader, followed by
+// fields of type uint8, uint16, uint32, uint64 (or types derived from
+// them) or slices thereof, in wire order. Fields tagged with
+// `encoding:"omit"` are skipped, which allows bit fields carried in the
+// type-specific byte to be exposed as regular fields.
 type ReportBlock interface {
 	DestinationSSRC() []uint32
-	setupBlockHeader()
-	unpackBlockHeader()
+	// SetupBlockHeader is called before the block is marshaled. It must
+	// fill in the embedded XRHeader, typically using ReportBlockLength to
+	// compute the BlockLength.
+	SetupBlockHeader()
+	// UnpackBlockHeader is called after the block has been unmarshaled,
+	// and should copy any values carried in the XRHeader (such as the
+	// type-specific byte) into the block's own fields.
+	UnpackBlockHeader()
+}
+
+// ReportBlockLength returns the value of the XRHeader BlockLength field
+// for the given report block: its encoded length in 32-bit words minus one.
+func ReportBlockLength(block ReportBlock) uint16 {
+	return uint16(wireSize(block)/4 - 1) //nolint:gosec // G115
 }
 
 // TypeSpecificField as described in RFC 3611 section 4.5. In typical
@@ -57,7 +77,9 @@ type XRHeader struct {
 // BlockTypeType specifies the type of report in a report block.
 type BlockTypeType uint8
 
-// Extended Report block types from RFC 3611.
+// Extended Report block types registered with IANA. See:
+//
+//	https://www.iana.org/assignments/rtcp-xr-block-types/rtcp-xr-block-types.xhtml
 const (
 	LossRLEReportBlockType               = 1 // RFC 3611, section 4.1
 	DuplicateRLEReportBlockType          = 2 // RFC 3611, section 4.2
@@ -66,6 +88,10 @@ const (
 	DLRRReportBlockType                  = 5 // RFC 3611, section 4.5
 	StatisticsSummaryReportBlockType     = 6 // RFC 3611, section 4.6
 	VoIPMetricsReportBlockType           = 7 // RFC 3611, section 4.7
+
+	MOSMetricsReportBlockType              = 29 // RFC 7266, section 4
+	LossConcealmentMetricsReportBlockType  = 30 // RFC 7294, section 3
+	ConcealedSecondsMetricsReportBlockType = 31 // RFC 7294, section 4
 )
 
 // String converts the Extended report block types into readable strings.
@@ -85,6 +111,12 @@ func (t BlockTypeType) String() string {
 		return "StatisticsSummaryReportBlockType"
 	case VoIPMetricsReportBlockType:
 		return "VoIPMetricsReportBlockType"
+	case MOSMetricsReportBlockType:
+		return "MOSMetricsReportBlockType"
+	case LossConcealmentMetricsReportBlockType:
+		return "LossConcealmentMetricsReportBlockType"
+	case ConcealedSecondsMetricsReportBlockType:
+		return "ConcealedSecondsMetricsReportBlockType"
 	}
 
 	return fmt.Sprintf("invalid value %d", t)
@@ -157,13 +189,13 @@ func (b *LossRLEReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{b.SSRC}
 }
 
-func (b *LossRLEReportBlock) setupBlockHeader() {
+func (b *LossRLEReportBlock) SetupBlockHeader() {
 	b.XRHeader.BlockType = LossRLEReportBlockType
 	b.XRHeader.TypeSpecific = TypeSpecificField(b.T & 0x0F)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+	b.XRHeader.BlockLength = ReportBlockLength(b)
 }
 
-func (b *LossRLEReportBlock) unpackBlockHeader() {
+func (b *LossRLEReportBlock) UnpackBlockHeader() {
 	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
 }
 
@@ -176,13 +208,13 @@ func (b *DuplicateRLEReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{b.SSRC}
 }
 
-func (b *DuplicateRLEReportBlock) setupBlockHeader() {
+func (b *DuplicateRLEReportBlock) SetupBlockHeader() {
 	b.XRHeader.BlockType = DuplicateRLEReportBlockType
 	b.XRHeader.TypeSpecific = TypeSpecificField(b.T & 0x0F)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+	b.XRHeader.BlockLength = ReportBlockLength(b)
 }
 
-func (b *DuplicateRLEReportBlock) unpackBlockHeader() {
+func (b *DuplicateRLEReportBlock) UnpackBlockHeader() {
 	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
 }
 
@@ -280,13 +312,13 @@ func (b *PacketReceiptTimesReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{b.SSRC}
 }
 
-func (b *PacketReceiptTimesReportBlock) setupBlockHeader() {
+func (b *PacketReceiptTimesReportBlock) SetupBlockHeader() {
 	b.XRHeader.BlockType = PacketReceiptTimesReportBlockType
 	b.XRHeader.TypeSpecific = TypeSpecificField(b.T & 0x0F)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+	b.XRHeader.BlockLength = ReportBlockLength(b)
 }
 
-func (b *PacketReceiptTimesReportBlock) unpackBlockHeader() {
+func (b *PacketReceiptTimesReportBlock) UnpackBlockHeader() {
 	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
 }
 
@@ -314,13 +346,13 @@ func (b *ReceiverReferenceTimeReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{}
 }
 
-func (b *ReceiverReferenceTimeReportBlock) setupBlockHeader() {
+func (b *ReceiverReferenceTimeReportBlock) SetupBlockHeader() {
 	b.XRHeader.BlockType = ReceiverReferenceTimeReportBlockType
 	b.XRHeader.TypeSpecific = 0
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+	b.XRHeader.BlockLength = ReportBlockLength(b)
 }
 
-func (b *ReceiverReferenceTimeReportBlock) unpackBlockHeader() {
+func (b *ReceiverReferenceTimeReportBlock) UnpackBlockHeader() {
 }
 
 // DLRRReportBlock encodes a DLRR Report Block as described in
@@ -365,13 +397,13 @@ func (b *DLRRReportBlock) DestinationSSRC() []uint32 {
 	return ssrc
 }
 
-func (b *DLRRReportBlock) setupBlockHeader() {
+func (b *DLRRReportBlock) SetupBlockHeader() {
 	b.XRHeader.BlockType = DLRRReportBlockType
 	b.XRHeader.TypeSpecific = 0
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+	b.XRHeader.BlockLength = ReportBlockLength(b)
 }
 
-func (b *DLRRReportBlock) unpackBlockHeader() {
+func (b *DLRRReportBlock) UnpackBlockHeader() {
 }
 
 // StatisticsSummaryReportBlock encodes a Statistics Summary Report
@@ -452,7 +484,7 @@ func (b *StatisticsSummaryReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{b.SSRC}
 }
 
-func (b *StatisticsSummaryReportBlock) setupBlockHeader() {
+func (b *StatisticsSummaryReportBlock) SetupBlockHeader() {
 	b.XRHeader.BlockType = StatisticsSummaryReportBlockType
 	b.XRHeader.TypeSpecific = 0x00
 	if b.LossReports {
@@ -465,10 +497,10 @@ func (b *StatisticsSummaryReportBlock) setupBlockHeader() {
 		b.XRHeader.TypeSpecific |= 0x20
 	}
 	b.XRHeader.TypeSpecific |= TypeSpecificField((b.TTLorHopLimit & 0x03) << 3)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+	b.XRHeader.BlockLength = ReportBlockLength(b)
 }
 
-func (b *StatisticsSummaryReportBlock) unpackBlockHeader() {
+func (b *StatisticsSummaryReportBlock) UnpackBlockHeader() {
 	b.LossReports = b.XRHeader.TypeSpecific&0x80 != 0
 	b.DuplicateReports = b.XRHeader.TypeSpecific&0x40 != 0
 	b.JitterReports = b.XRHeader.TypeSpecific&0x20 != 0
@@ -532,13 +564,342 @@ func (b *VoIPMetricsReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{b.SSRC}
 }
 
-func (b *VoIPMetricsReportBlock) setupBlockHeader() {
+func (b *VoIPMetricsReportBlock) SetupBlockHeader() {
 	b.XRHeader.BlockType = VoIPMetricsReportBlockType
 	b.XRHeader.TypeSpecific = 0
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+	b.XRHeader.BlockLength = ReportBlockLength(b)
 }
 
-func (b *VoIPMetricsReportBlock) unpackBlockHeader() {
+func (b *VoIPMetricsReportBlock) UnpackBlockHeader() {
+}
+
+// IntervalMetricFlag encodes the I field that appears in the type-specific
+// byte of the performance metric report blocks, as described in
+// RFC 6792 section 5.1.
+type IntervalMetricFlag uint8
+
+// Values for IntervalMetricFlag.
+const (
+	IntervalMetricSampled    = 1
+	IntervalMetricInterval   = 2
+	IntervalMetricCumulative = 3
+)
+
+func (f IntervalMetricFlag) String() string {
+	switch f {
+	case IntervalMetricSampled:
+		return "[Sampled]"
+	case IntervalMetricInterval:
+		return "[Interval]"
+	case IntervalMetricCumulative:
+		return "[Cumulative]"
+	}
+
+	return "[Interval Metric Flag is Invalid]"
+}
+
+// PacketLossConcealmentType encodes the plc field of the concealment
+// metrics report blocks, as described in RFC 7294 section 3.1.
+type PacketLossConcealmentType uint8
+
+// Values for PacketLossConcealmentType.
+const (
+	PLCSilenceInsertion            = 0
+	PLCSimpleReplayNoAttenuation   = 1
+	PLCSimpleReplayWithAttenuation = 2
+	PLCEnhancedInterpolation       = 3
+)
+
+func (t PacketLossConcealmentType) String() string {
+	switch t {
+	case PLCSilenceInsertion:
+		return "[PLC = Silence Insertion]"
+	case PLCSimpleReplayNoAttenuation:
+		return "[PLC = Simple Replay, No Attenuation]"
+	case PLCSimpleReplayWithAttenuation:
+		return "[PLC = Simple Replay, With Attenuation]"
+	case PLCEnhancedInterpolation:
+		return "[PLC = Enhanced Interpolation]"
+	}
+
+	return "[PLC Type is Invalid]"
+}
+
+// LossConcealmentMetricsReportBlock encodes a Loss Concealment Metrics
+// Report Block as described in RFC 7294, section 3. All durations are
+// expressed in RTP timestamp units.
+//
+//	0                   1                   2                   3
+//	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |    BT=30      | I |plc|  rsv  |       block length = 6        |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                        SSRC of source                         |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                   On-Time Playout Duration                    |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                   Loss Concealment Duration                   |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |            Buffer Adjustment Concealment Duration             |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |    Playout Interrupt Count    |           Reserved            |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                  Mean Playout Interrupt Size                  |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// .
+type LossConcealmentMetricsReportBlock struct {
+	XRHeader
+	Interval                            IntervalMetricFlag        `encoding:"omit"`
+	PLC                                 PacketLossConcealmentType `encoding:"omit"`
+	SSRC                                uint32                    `fmt:"0x%X"`
+	OnTimePlayoutDuration               uint32
+	LossConcealmentDuration             uint32
+	BufferAdjustmentConcealmentDuration uint32
+	PlayoutInterruptCount               uint16
+	_                                   uint16
+	MeanPlayoutInterruptSize            uint32
+}
+
+// DestinationSSRC returns an array of SSRC values that this report block refers to.
+func (b *LossConcealmentMetricsReportBlock) DestinationSSRC() []uint32 {
+	return []uint32{b.SSRC}
+}
+
+// ConcealedPercent returns the share of the total playout duration,
+// in percent, that was produced by loss or buffer adjustment concealment
+// rather than by on-time media.
+func (b *LossConcealmentMetricsReportBlock) ConcealedPercent() float64 {
+	concealed := float64(b.LossConcealmentDuration) + float64(b.BufferAdjustmentConcealmentDuration)
+	total := float64(b.OnTimePlayoutDuration) + concealed
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * concealed / total
+}
+
+func (b *LossConcealmentMetricsReportBlock) SetupBlockHeader() {
+	b.XRHeader.BlockType = LossConcealmentMetricsReportBlockType
+	b.XRHeader.TypeSpecific = TypeSpecificField((b.Interval&0x03)<<6) |
+		TypeSpecificField((b.PLC&0x03)<<4)
+	b.XRHeader.BlockLength = ReportBlockLength(b)
+}
+
+func (b *LossConcealmentMetricsReportBlock) UnpackBlockHeader() {
+	b.Interval = IntervalMetricFlag((b.XRHeader.TypeSpecific >> 6) & 0x03)
+	b.PLC = PacketLossConcealmentType((b.XRHeader.TypeSpecific >> 4) & 0x03)
+}
+
+// ConcealedSecondsMetricsReportBlock encodes a Concealed Seconds Metrics
+// Report Block as described in RFC 7294, section 4.
+//
+//	0                   1                   2                   3
+//	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |    BT=31      | I |plc|  rsv  |       block length = 4        |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                        SSRC of source                         |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                      Unimpaired Seconds                       |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                       Concealed Seconds                       |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |  Severely Concealed Seconds   |   Reserved    | SCS Threshold |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// .
+type ConcealedSecondsMetricsReportBlock struct {
+	XRHeader
+	Interval                 IntervalMetricFlag        `encoding:"omit"`
+	PLC                      PacketLossConcealmentType `encoding:"omit"`
+	SSRC                     uint32                    `fmt:"0x%X"`
+	UnimpairedSeconds        uint32
+	ConcealedSeconds         uint32
+	SeverelyConcealedSeconds uint16
+	_                        uint8
+	// Share of a one second period that must be concealed for it to
+	// count as severely concealed, in units of 0.1 percent.
+	SCSThreshold uint8
+}
+
+// DestinationSSRC returns an array of SSRC values that this report block refers to.
+func (b *ConcealedSecondsMetricsReportBlock) DestinationSSRC() []uint32 {
+	return []uint32{b.SSRC}
+}
+
+// ConcealedSecondsPercent returns the percentage of the reported seconds
+// during which any concealment took place.
+func (b *ConcealedSecondsMetricsReportBlock) ConcealedSecondsPercent() float64 {
+	total := float64(b.UnimpairedSeconds) + float64(b.ConcealedSeconds)
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(b.ConcealedSeconds) / total
+}
+
+// SeverelyConcealedSecondsPercent returns the percentage of the reported
+// seconds that were severely concealed.
+func (b *ConcealedSecondsMetricsReportBlock) SeverelyConcealedSecondsPercent() float64 {
+	total := float64(b.UnimpairedSeconds) + float64(b.ConcealedSeconds)
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(b.SeverelyConcealedSeconds) / total
+}
+
+func (b *ConcealedSecondsMetricsReportBlock) SetupBlockHeader() {
+	b.XRHeader.BlockType = ConcealedSecondsMetricsReportBlockType
+	b.XRHeader.TypeSpecific = TypeSpecificField((b.Interval&0x03)<<6) |
+		TypeSpecificField((b.PLC&0x03)<<4)
+	b.XRHeader.BlockLength = ReportBlockLength(b)
+}
+
+func (b *ConcealedSecondsMetricsReportBlock) UnpackBlockHeader() {
+	b.Interval = IntervalMetricFlag((b.XRHeader.TypeSpecific >> 6) & 0x03)
+	b.PLC = PacketLossConcealmentType((b.XRHeader.TypeSpecific >> 4) & 0x03)
+}
+
+// MOSMetricsReportBlock encodes a MOS Metrics Report Block as described
+// in RFC 7266, section 4. It carries one MOSSegment per reported
+// payload type or audio channel.
+//
+//	0                   1                   2                   3
+//	0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |    BT=29      | I |   resv    |         block length          |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                        SSRC of source                         |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                           Segment 1                           |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// :                              ...                              :
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |                           Segment n                           |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// .
+type MOSMetricsReportBlock struct {
+	XRHeader
+	Interval IntervalMetricFlag `encoding:"omit"`
+	SSRC     uint32             `fmt:"0x%X"`
+	Segments []MOSSegment
+}
+
+// MOSSegment as defined in RFC 7266, section 4. Segments come in two
+// forms, distinguished by the S bit:
+//
+// Single Channel Audio/Video Per-SSRC Segment:
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|S|     PT      |     CAID      |           MOS Value           |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// Multi-Channel Audio Per-Channel Segment:
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|S|CHID | rsvd  |     CAID      |           MOS Value           |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+type MOSSegment uint32
+
+// MOS values with special meaning, as described in RFC 7266 section 4.
+const (
+	MOSValueOutOfRange  = 0xFFFE
+	MOSValueUnavailable = 0xFFFF
+)
+
+// NewSingleChannelMOSSegment returns a segment reporting the MOS of
+// a single channel stream using the given payload type and calculation
+// algorithm. The value is expressed in units of 0.01.
+func NewSingleChannelMOSSegment(payloadType, calculationAlgorithm uint8, value uint16) MOSSegment {
+	return MOSSegment(uint32(payloadType&0x7F)<<24 | uint32(calculationAlgorithm)<<16 | uint32(value))
+}
+
+// NewMultiChannelMOSSegment returns a segment reporting the MOS of
+// a single channel of a multi-channel audio stream. The value is
+// expressed in units of 0.01.
+func NewMultiChannelMOSSegment(channelID, calculationAlgorithm uint8, value uint16) MOSSegment {
+	return MOSSegment(1<<31 | uint32(channelID&0x07)<<28 | uint32(calculationAlgorithm)<<16 | uint32(value))
+}
+
+func (s MOSSegment) String() string {
+	if s.MultiChannel() {
+		return fmt.Sprintf("[MultiChannel chid=%d, caid=%d, mos=%d]",
+			s.ChannelID(), s.CalculationAlgorithm(), s.Value())
+	}
+
+	return fmt.Sprintf("[SingleChannel pt=%d, caid=%d, mos=%d]",
+		s.PayloadType(), s.CalculationAlgorithm(), s.Value())
+}
+
+// MultiChannel reports whether this is a Multi-Channel Audio Per-Channel
+// segment.
+func (s MOSSegment) MultiChannel() bool {
+	return s>>31 != 0
+}
+
+// PayloadType returns the payload type this segment refers to. It is
+// only valid for single channel segments.
+func (s MOSSegment) PayloadType() uint8 {
+	if s.MultiChannel() {
+		return 0
+	}
+
+	return uint8((s >> 24) & 0x7F) //nolint:gosec // G115
+}
+
+// ChannelID returns the audio channel this segment refers to. It is
+// only valid for multi-channel segments.
+func (s MOSSegment) ChannelID() uint8 {
+	if !s.MultiChannel() {
+		return 0
+	}
+
+	return uint8((s >> 28) & 0x07) //nolint:gosec // G115
+}
+
+// CalculationAlgorithm returns the identifier of the algorithm that
+// was used to compute the MOS value.
+func (s MOSSegment) CalculationAlgorithm() uint8 {
+	return uint8((s >> 16) & 0xFF) //nolint:gosec // G115
+}
+
+// Value returns the raw MOS value carried in this segment.
+func (s MOSSegment) Value() uint16 {
+	return uint16(s & 0xFFFF) //nolint:gosec // G115
+}
+
+// MOS returns the MOS value of the segment as a score. The second result
+// is false if the value is one of MOSValueOutOfRange or
+// MOSValueUnavailable.
+func (s MOSSegment) MOS() (float64, bool) {
+	value := s.Value()
+	if value == MOSValueOutOfRange || value == MOSValueUnavailable {
+		return 0, false
+	}
+
+	return float64(value) / 100, true
+}
+
+// DestinationSSRC returns an array of SSRC values that this report block refers to.
+func (b *MOSMetricsReportBlock) DestinationSSRC() []uint32 {
+	return []uint32{b.SSRC}
+}
+
+func (b *MOSMetricsReportBlock) SetupBlockHeader() {
+	b.XRHeader.BlockType = MOSMetricsReportBlockType
+	b.XRHeader.TypeSpecific = TypeSpecificField((b.Interval & 0x03) << 6)
+	b.XRHeader.BlockLength = ReportBlockLength(b)
+}
+
+func (b *MOSMetricsReportBlock) UnpackBlockHeader() {
+	b.Interval = IntervalMetricFlag((b.XRHeader.TypeSpecific >> 6) & 0x03)
 }
 
 // UnknownReportBlock is used to store bytes for any report block
@@ -553,11 +914,11 @@ func (b *UnknownReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{}
 }
 
-func (b *UnknownReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+func (b *UnknownReportBlock) SetupBlockHeader() {
+	b.XRHeader.BlockLength = ReportBlockLength(b)
 }
 
-func (b *UnknownReportBlock) unpackBlockHeader() {
+func (b *UnknownReportBlock) UnpackBlockHeader() {
 }
 
 // MarshalSize returns the size of the packet once marshaled.
@@ -568,7 +929,7 @@ func (x ExtendedReport) MarshalSize() int {
 // Marshal encodes the ExtendedReport in binary.
 func (x ExtendedReport) Marshal() ([]byte, error) {
 	for _, p := range x.Reports {
-		p.setupBlockHeader()
+		p.SetupBlockHeader()
 	}
 
 	length := wireSize(x)
@@ -600,8 +961,6 @@ func (x ExtendedReport) Marshal() ([]byte, error) {
 }
 
 // Unmarshal decodes the ExtendedReport from binary.
-//
-//nolint:cyclop
 func (x *ExtendedReport) Unmarshal(b []byte) error {
 	var header Header
 	if err := header.Unmarshal(b); err != nil {
@@ -618,8 +977,6 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 	}
 
 	for len(buffer.bytes) > 0 {
-		var block ReportBlock
-
 		headerBuffer := buffer
 		xrHeader := XRHeader{}
 		err = headerBuffer.read(&xrHeader)
@@ -627,24 +984,7 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 			return err
 		}
 
-		switch xrHeader.BlockType {
-		case LossRLEReportBlockType:
-			block = new(LossRLEReportBlock)
-		case DuplicateRLEReportBlockType:
-			block = new(DuplicateRLEReportBlock)
-		case PacketReceiptTimesReportBlockType:
-			block = new(PacketReceiptTimesReportBlock)
-		case ReceiverReferenceTimeReportBlockType:
-			block = new(ReceiverReferenceTimeReportBlock)
-		case DLRRReportBlockType:
-			block = new(DLRRReportBlock)
-		case StatisticsSummaryReportBlockType:
-			block = new(StatisticsSummaryReportBlock)
-		case VoIPMetricsReportBlockType:
-			block = new(VoIPMetricsReportBlock)
-		default:
-			block = new(UnknownReportBlock)
-		}
+		block := newReportBlock(xrHeader.BlockType)
 
 		// We need to limit the amount of data available to
 		// this block to the actual length of the block
@@ -654,13 +994,94 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 		if err != nil {
 			return err
 		}
-		block.unpackBlockHeader()
+		block.UnpackBlockHeader()
 		x.Reports = append(x.Reports, block)
 	}
 
 	return nil
 }
 
+// newReportBlock is a factory which returns an empty report block for the
+// given block type. Types without a built-in or registered implementation
+// are decoded as UnknownReportBlock.
+//
+//nolint:cyclop
+func newReportBlock(blockType BlockTypeType) ReportBlock {
+	switch blockType {
+	case LossRLEReportBlockType:
+		return new(LossRLEReportBlock)
+	case DuplicateRLEReportBlockType:
+		return new(DuplicateRLEReportBlock)
+	case PacketReceiptTimesReportBlockType:
+		return new(PacketReceiptTimesReportBlock)
+	case ReceiverReferenceTimeReportBlockType:
+		return new(ReceiverReferenceTimeReportBlock)
+	case DLRRReportBlockType:
+		return new(DLRRReportBlock)
+	case StatisticsSummaryReportBlockType:
+		return new(StatisticsSummaryReportBlock)
+	case VoIPMetricsReportBlockType:
+		return new(VoIPMetricsReportBlock)
+	case LossConcealmentMetricsReportBlockType:
+		return new(LossConcealmentMetricsReportBlock)
+	case ConcealedSecondsMetricsReportBlockType:
+		return new(ConcealedSecondsMetricsReportBlock)
+	case MOSMetricsReportBlockType:
+		return new(MOSMetricsReportBlock)
+	}
+
+	reportBlockRegistry.RLock()
+	newBlock, ok := reportBlockRegistry.blocks[blockType]
+	reportBlockRegistry.RUnlock()
+	if ok {
+		return newBlock()
+	}
+
+	return new(UnknownReportBlock)
+}
+
+//nolint:gochecknoglobals
+var reportBlockRegistry = struct {
+	sync.RWMutex
+	blocks map[BlockTypeType]func() ReportBlock
+}{blocks: map[BlockTypeType]func() ReportBlock{}}
+
+// RegisterReportBlock registers a constructor for report blocks of the
+// given type, so that ExtendedReport.Unmarshal decodes them into the
+// returned ReportBlock instead of an UnknownReportBlock. The constructor
+// must return a new, empty block on every call.
+//
+// Block types implemented by this package cannot be overridden, and
+// each block type may only be registered once.
+func RegisterReportBlock(blockType BlockTypeType, newBlock func() ReportBlock) error {
+	if newBlock == nil {
+		return errNilReportBlockConstructor
+	}
+	if _, ok := newReportBlock(blockType).(*UnknownReportBlock); !ok {
+		return errReportBlockTypeRegistered
+	}
+
+	reportBlockRegistry.Lock()
+	defer reportBlockRegistry.Unlock()
+
+	if _, ok := reportBlockRegistry.blocks[blockType]; ok {
+		return errReportBlockTypeRegistered
+	}
+	reportBlockRegistry.blocks[blockType] = newBlock
+
+	return nil
+}
+
+// UnregisterReportBlock removes a constructor previously added with
+// RegisterReportBlock. Report blocks of this type will be decoded as
+// UnknownReportBlock again.
+func UnregisterReportBlock(blockType BlockTypeType) {
+	reportBlockRegistry.Lock()
+	defer reportBlockRegistry.Unlock()
+
+	delete(reportBlockRegistry.blocks, blockType)
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (x *ExtendedReport) DestinationSSRC() []uint32 {
 	ssrc := make([]uint32, 0, len(x.Reports)+1)
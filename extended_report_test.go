@@ -22,6 +22,9 @@ var (
 	_ ReportBlock = (*DLRRReportBlock)(nil)
 	_ ReportBlock = (*StatisticsSummaryReportBlock)(nil)
 	_ ReportBlock = (*VoIPMetricsReportBlock)(nil)
+	_ ReportBlock = (*LossConcealmentMetricsReportBlock)(nil)
+	_ ReportBlock = (*ConcealedSecondsMetricsReportBlock)(nil)
+	_ ReportBlock = (*MOSMetricsReportBlock)(nil)
 	_ ReportBlock = (*UnknownReportBlock)(nil)
 )
 
@@ -244,7 +247,7 @@ func TestDecode(t *testing.T) {
 	assert.True(t, ok)
 
 	for _, p := range extendedReports.Reports {
-		p.setupBlockHeader()
+		p.SetupBlockHeader()
 	}
 
 	report := new(ExtendedReport)
@@ -264,3 +267,178 @@ func TestDecode(t *testing.T) {
 	}
 	assert.True(t, includeSenderSSRC, "DestinationSSRC does not include the SenderSSRC")
 }
+
+func TestConcealmentAndMOSMetricsRoundTrip(t *testing.T) {
+	encoded := []byte{
+		// RTP Header
+		0x80, 0xCF, 0x00, 0x11,
+		// Sender SSRC
+		0x01, 0x02, 0x03, 0x04,
+		// Loss Concealment Metrics Report Block
+		0x1E, 0xB0, 0x00, 0x06,
+		// Source SSRC
+		0x12, 0x34, 0x56, 0x78,
+		// On-Time Playout Duration
+		0x00, 0x00, 0x1F, 0x40,
+		// Loss Concealment Duration
+		0x00, 0x00, 0x01, 0x40,
+		// Buffer Adjustment Concealment Duration
+		0x00, 0x00, 0x00, 0xA0,
+		// Playout Interrupt Count, reserved
+		0x00, 0x03, 0x00, 0x00,
+		// Mean Playout Interrupt Size
+		0x00, 0x00, 0x00, 0xA0,
+		// Concealed Seconds Metrics Report Block
+		0x1F, 0xC0, 0x00, 0x04,
+		// Source SSRC
+		0x12, 0x34, 0x56, 0x78,
+		// Unimpaired Seconds
+		0x00, 0x00, 0x00, 0x5A,
+		// Concealed Seconds
+		0x00, 0x00, 0x00, 0x0A,
+		// Severely Concealed Seconds, reserved, SCS Threshold
+		0x00, 0x02, 0x00, 0x32,
+		// MOS Metrics Report Block
+		0x1D, 0x80, 0x00, 0x03,
+		// Source SSRC
+		0x12, 0x34, 0x56, 0x78,
+		// Single channel segment, PT=111, CAID=1, MOS=4.12
+		0x6F, 0x01, 0x01, 0x9C,
+		// Multi-channel segment, CHID=2, CAID=1, unavailable
+		0xA0, 0x01, 0xFF, 0xFF,
+	}
+	expected := &ExtendedReport{
+		SenderSSRC: 0x01020304,
+		Reports: []ReportBlock{
+			&LossConcealmentMetricsReportBlock{
+				Interval:                            IntervalMetricInterval,
+				PLC:                                 PLCEnhancedInterpolation,
+				SSRC:                                0x12345678,
+				OnTimePlayoutDuration:               8000,
+				LossConcealmentDuration:             320,
+				BufferAdjustmentConcealmentDuration: 160,
+				PlayoutInterruptCount:               3,
+				MeanPlayoutInterruptSize:            160,
+			},
+			&ConcealedSecondsMetricsReportBlock{
+				Interval:                 IntervalMetricCumulative,
+				PLC:                      PLCSilenceInsertion,
+				SSRC:                     0x12345678,
+				UnimpairedSeconds:        90,
+				ConcealedSeconds:         10,
+				SeverelyConcealedSeconds: 2,
+				SCSThreshold:             50,
+			},
+			&MOSMetricsReportBlock{
+				Interval: IntervalMetricInterval,
+				SSRC:     0x12345678,
+				Segments: []MOSSegment{
+					NewSingleChannelMOSSegment(111, 1, 412),
+					NewMultiChannelMOSSegment(2, 1, MOSValueUnavailable),
+				},
+			},
+		},
+	}
+
+	rawPacket, err := expected.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, rawPacket)
+
+	decoded := new(ExtendedReport)
+	assert.NoError(t, decoded.Unmarshal(encoded))
+	assert.Equal(t, expected, decoded)
+
+	concealment, ok := decoded.Reports[0].(*LossConcealmentMetricsReportBlock)
+	assert.True(t, ok)
+	assert.InDelta(t, 5.66, concealment.ConcealedPercent(), 0.01)
+
+	seconds, ok := decoded.Reports[1].(*ConcealedSecondsMetricsReportBlock)
+	assert.True(t, ok)
+	assert.InDelta(t, 10.0, seconds.ConcealedSecondsPercent(), 0.001)
+	assert.InDelta(t, 2.0, seconds.SeverelyConcealedSecondsPercent(), 0.001)
+
+	mos, ok := decoded.Reports[2].(*MOSMetricsReportBlock)
+	assert.True(t, ok)
+	assert.False(t, mos.Segments[0].MultiChannel())
+	assert.Equal(t, uint8(111), mos.Segments[0].PayloadType())
+	assert.Equal(t, uint8(1), mos.Segments[0].CalculationAlgorithm())
+	score, valid := mos.Segments[0].MOS()
+	assert.True(t, valid)
+	assert.InDelta(t, 4.12, score, 0.001)
+	assert.True(t, mos.Segments[1].MultiChannel())
+	assert.Equal(t, uint8(2), mos.Segments[1].ChannelID())
+	_, valid = mos.Segments[1].MOS()
+	assert.False(t, valid)
+
+	assert.Equal(t, 0.0, (&LossConcealmentMetricsReportBlock{}).ConcealedPercent())
+	assert.Equal(t, 0.0, (&ConcealedSecondsMetricsReportBlock{}).ConcealedSecondsPercent())
+}
+
+type vendorReportBlock struct {
+	XRHeader
+	Flag   uint8  `encoding:"omit"`
+	SSRC   uint32 `fmt:"0x%X"`
+	Values []uint16
+}
+
+func (b *vendorReportBlock) DestinationSSRC() []uint32 {
+	return []uint32{b.SSRC}
+}
+
+func (b *vendorReportBlock) SetupBlockHeader() {
+	b.XRHeader.BlockType = 200
+	b.XRHeader.TypeSpecific = TypeSpecificField(b.Flag)
+	b.XRHeader.BlockLength = ReportBlockLength(b)
+}
+
+func (b *vendorReportBlock) UnpackBlockHeader() {
+	b.Flag = uint8(b.XRHeader.TypeSpecific)
+}
+
+func TestRegisterReportBlock(t *testing.T) {
+	encoded := []byte{
+		// RTP Header
+		0x80, 0xCF, 0x00, 0x04,
+		// Sender SSRC
+		0x01, 0x02, 0x03, 0x04,
+		// Vendor Report Block
+		0xC8, 0x07, 0x00, 0x02,
+		// Source SSRC
+		0x12, 0x34, 0x56, 0x78,
+		// Values
+		0x00, 0x01, 0x00, 0x02,
+	}
+	expected := &ExtendedReport{
+		SenderSSRC: 0x01020304,
+		Reports: []ReportBlock{
+			&vendorReportBlock{
+				Flag:   7,
+				SSRC:   0x12345678,
+				Values: []uint16{1, 2},
+			},
+		},
+	}
+
+	// Without registration, the block is preserved as an UnknownReportBlock
+	decoded := new(ExtendedReport)
+	assert.NoError(t, decoded.Unmarshal(encoded))
+	assert.IsType(t, &UnknownReportBlock{}, decoded.Reports[0])
+
+	assert.NoError(t, RegisterReportBlock(200, func() ReportBlock { return new(vendorReportBlock) }))
+	defer UnregisterReportBlock(200)
+
+	assert.ErrorIs(t, RegisterReportBlock(200, func() ReportBlock { return new(vendorReportBlock) }),
+		errReportBlockTypeRegistered)
+	assert.ErrorIs(t, RegisterReportBlock(DLRRReportBlockType, func() ReportBlock { return new(vendorReportBlock) }),
+		errReportBlockTypeRegistered)
+	assert.ErrorIs(t, RegisterReportBlock(201, nil), errNilReportBlockConstructor)
+
+	rawPacket, err := expected.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, rawPacket)
+
+	decoded = new(ExtendedReport)
+	assert.NoError(t, decoded.Unmarshal(encoded))
+	assert.Equal(t, expected, decoded)
+	assert.Equal(t, []uint32{0x01020304, 0x12345678}, decoded.DestinationSSRC())
+}
@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"reflect"
+	"slices"
+	"time"
+)
+
+const (
+	// Fraction of the regular RTCP interval over which early feedback is
+	// dithered in multiparty sessions, see RFC 4585 section 3.4.
+	feedbackDitherFraction = 0.5
+	// Group size up to which immediate feedback mode is used by default.
+	defaultImmediateFeedbackMembers = 2
+)
+
+// FeedbackMode is the operation mode of an AVPF receiver, as described in
+// RFC 4585 section 3.3.
+type FeedbackMode int
+
+// Feedback modes.
+const (
+	// ImmediateFeedbackMode is used when the group is small enough for early
+	// RTCP packets to be sent without dithering.
+	ImmediateFeedbackMode FeedbackMode = iota + 1
+	// EarlyRTCPMode allows one early RTCP packet between two regular ones.
+	EarlyRTCPMode
+	// RegularRTCPMode sends feedback in regular RTCP packets only.
+	RegularRTCPMode
+)
+
+// FeedbackDecision tells how a feedback packet will be sent.
+type FeedbackDecision int
+
+// Feedback decisions.
+const (
+	// FeedbackSendEarly means the feedback is sent in an early RTCP packet
+	// at the returned time, which may be now.
+	FeedbackSendEarly FeedbackDecision = iota + 1
+	// FeedbackWaitRegular means the feedback is sent in the next regular
+	// RTCP packet, scheduled at the returned time.
+	FeedbackWaitRegular
+	// FeedbackSuppress means the feedback must not be sent, because the
+	// same information is already pending.
+	FeedbackSuppress
+)
+
+// FeedbackSchedulerConfig configures a FeedbackScheduler.
+type FeedbackSchedulerConfig struct {
+	IntervalConfig
+
+	// MinimumRegularInterval is T_rr_interval from RFC 4585 section 3.4, the
+	// minimum interval between regular RTCP packets that carry no feedback.
+	// Zero disables it.
+	MinimumRegularInterval time.Duration
+
+	// ImmediateFeedbackMembers is the largest group size for which
+	// immediate feedback mode is used. If zero, it is used for point to
+	// point sessions only.
+	ImmediateFeedbackMembers int
+
+	// EarlyRTCPMembers is the largest group size for which early RTCP mode
+	// is used, larger groups use regular RTCP mode. If zero, early RTCP
+	// mode is used regardless of the group size.
+	EarlyRTCPMembers int
+}
+
+// FeedbackScheduler decides when feedback packets such as
+// TransportLayerNack or PictureLossIndication may be sent, following the
+// timing rules of the Extended RTP Profile for RTCP-Based Feedback (AVPF)
+// in RFC 4585 section 3.5: feedback goes out in an early RTCP packet when
+// allowed, dithered in multiparty sessions, and otherwise waits for the
+// next regular RTCP packet. Feedback that is already pending, or that is
+// received from another member before it is sent, is suppressed.
+//
+// The regular RTCP schedule is that of the underlying IntervalCalculator,
+// returned by Calculator, which uses the AVPF minimum interval and must be
+// kept informed of the session members and received RTCP packets. Its
+// Expire and PacketSent methods are replaced by RegularFeedback and
+// RegularSent.
+//
+// FeedbackScheduler is not safe for concurrent use.
+type FeedbackScheduler struct {
+	config     FeedbackSchedulerConfig
+	calculator *IntervalCalculator
+
+	allowEarly      bool
+	earlySent       bool
+	earlyScheduled  time.Time
+	lastRegularSent time.Time
+	minimumInterval time.Duration
+
+	pendingEarly   []Packet
+	pendingRegular []Packet
+}
+
+// NewFeedbackScheduler returns a FeedbackScheduler for a participant
+// joining the session at the given time.
+func NewFeedbackScheduler(config FeedbackSchedulerConfig, now time.Time) *FeedbackScheduler {
+	if config.ImmediateFeedbackMembers == 0 {
+		config.ImmediateFeedbackMembers = defaultImmediateFeedbackMembers
+	}
+	config.AVPF = true
+
+	scheduler := &FeedbackScheduler{
+		config:          config,
+		calculator:      NewIntervalCalculator(config.IntervalConfig, now),
+		allowEarly:      true,
+		lastRegularSent: now,
+	}
+	scheduler.updateMinimumInterval()
+
+	return scheduler
+}
+
+// Calculator returns the IntervalCalculator scheduling regular RTCP packets.
+func (s *FeedbackScheduler) Calculator() *IntervalCalculator {
+	return s.calculator
+}
+
+// Mode returns the feedback mode for the current group size.
+func (s *FeedbackScheduler) Mode() FeedbackMode {
+	switch members := s.calculator.members; {
+	case members <= s.config.ImmediateFeedbackMembers:
+		return ImmediateFeedbackMode
+	case s.config.EarlyRTCPMembers > 0 && members > s.config.EarlyRTCPMembers:
+		return RegularRTCPMode
+	default:
+		return EarlyRTCPMode
+	}
+}
+
+// Schedule decides how the given feedback packet, generated at the given
+// time, is sent, and returns the time at which it is due. Depending on the
+// decision, the packet is then returned by EarlyFeedback or
+// RegularFeedback, unless it gets suppressed in the meantime.
+func (s *FeedbackScheduler) Schedule(feedback Packet, now time.Time) (FeedbackDecision, time.Time) {
+	if s.isPending(feedback) {
+		return FeedbackSuppress, time.Time{}
+	}
+
+	mode := s.Mode()
+	if mode == RegularRTCPMode {
+		return s.waitRegular(feedback)
+	}
+
+	// Join the early RTCP packet that is already scheduled
+	if !s.earlyScheduled.IsZero() {
+		s.pendingEarly = append(s.pendingEarly, feedback)
+
+		return FeedbackSendEarly, s.earlyScheduled
+	}
+
+	ditherMax := s.ditherMax()
+	if now.Add(ditherMax).After(s.calculator.nextScheduled) {
+		return s.waitRegular(feedback)
+	}
+	if !s.allowEarly {
+		return s.waitRegular(feedback)
+	}
+
+	s.earlyScheduled = now.Add(time.Duration(s.calculator.config.Random() * float64(ditherMax)))
+	s.pendingEarly = append(s.pendingEarly, feedback)
+
+	return FeedbackSendEarly, s.earlyScheduled
+}
+
+// Received records feedback received from another member, so that
+// pending feedback carrying the same information is suppressed.
+func (s *FeedbackScheduler) Received(feedback Packet) {
+	covered := func(pending Packet) bool {
+		return feedbackCovers(feedback, pending)
+	}
+	s.pendingEarly = slices.DeleteFunc(s.pendingEarly, covered)
+	s.pendingRegular = slices.DeleteFunc(s.pendingRegular, covered)
+}
+
+// NextEarly returns the time at which the next early RTCP packet is
+// scheduled, if any.
+func (s *FeedbackScheduler) NextEarly() (time.Time, bool) {
+	return s.earlyScheduled, !s.earlyScheduled.IsZero()
+}
+
+// EarlyFeedback returns the feedback to send in an early RTCP packet if one
+// is due at the given time. It returns false if no early RTCP packet must
+// be sent, in particular if all of its feedback was suppressed. Otherwise,
+// EarlySent must be called once the packet has been sent.
+func (s *FeedbackScheduler) EarlyFeedback(now time.Time) ([]Packet, bool) {
+	if s.earlyScheduled.IsZero() || s.earlyScheduled.After(now) {
+		return nil, false
+	}
+
+	s.earlyScheduled = time.Time{}
+	feedback := s.pendingEarly
+	s.pendingEarly = nil
+
+	return feedback, len(feedback) > 0
+}
+
+// EarlySent records that an early RTCP packet of the given size in octets
+// was sent. No other early RTCP packet is allowed until the next regular
+// one, which is delayed by a full interval to keep within the RTCP
+// bandwidth.
+func (s *FeedbackScheduler) EarlySent(size int) {
+	s.calculator.updateAverage(size)
+
+	s.allowEarly = false
+	if !s.earlySent {
+		s.earlySent = true
+		c := s.calculator
+		c.nextScheduled = c.nextScheduled.Add(c.nextScheduled.Sub(c.lastSent))
+	}
+}
+
+// NextRegular returns the time at which the next regular RTCP packet is
+// scheduled. RegularFeedback should be called at that time.
+func (s *FeedbackScheduler) NextRegular() time.Time {
+	return s.calculator.nextScheduled
+}
+
+// RegularFeedback applies timer reconsideration when the regular RTCP
+// timer fires. It returns true if a regular RTCP packet should be sent now,
+// together with the feedback to include in it, in which case RegularSent
+// must be called once it has been sent. Otherwise, the packet has been
+// rescheduled to NextRegular, or skipped because of the minimum regular
+// interval.
+func (s *FeedbackScheduler) RegularFeedback(now time.Time) ([]Packet, bool) {
+	c := s.calculator
+	interval := c.Interval()
+	if s.earlySent {
+		interval *= 2
+	}
+	if next := c.lastSent.Add(interval); next.After(now) {
+		c.nextScheduled = next
+
+		return nil, false
+	}
+
+	// Regular RTCP packets without feedback are limited by T_rr_interval,
+	// counted from the last regular packet actually sent
+	if len(s.pendingRegular) == 0 && now.Before(s.lastRegularSent.Add(s.minimumInterval)) {
+		c.lastSent = now
+		c.pmembers = c.members
+		c.nextScheduled = now.Add(c.Interval())
+		s.regularDone()
+
+		return nil, false
+	}
+
+	feedback := s.pendingRegular
+	s.pendingRegular = nil
+
+	return feedback, true
+}
+
+// RegularSent records that a regular RTCP packet of the given size in
+// octets was sent at the given time, and schedules the next one.
+func (s *FeedbackScheduler) RegularSent(size int, now time.Time) {
+	s.calculator.PacketSent(size, now)
+	s.lastRegularSent = now
+	s.updateMinimumInterval()
+	s.regularDone()
+}
+
+func (s *FeedbackScheduler) regularDone() {
+	s.allowEarly = true
+	s.earlySent = false
+}
+
+func (s *FeedbackScheduler) updateMinimumInterval() {
+	random := s.calculator.config.Random() + 0.5
+	s.minimumInterval = time.Duration(random * float64(s.config.MinimumRegularInterval))
+}
+
+// ditherMax returns T_dither_max, see RFC 4585 section 3.4.
+func (s *FeedbackScheduler) ditherMax() time.Duration {
+	if s.Mode() == ImmediateFeedbackMode {
+		return 0
+	}
+
+	return time.Duration(feedbackDitherFraction * float64(s.calculator.DeterministicInterval()))
+}
+
+func (s *FeedbackScheduler) waitRegular(feedback Packet) (FeedbackDecision, time.Time) {
+	s.pendingRegular = append(s.pendingRegular, feedback)
+
+	return FeedbackWaitRegular, s.calculator.nextScheduled
+}
+
+func (s *FeedbackScheduler) isPending(feedback Packet) bool {
+	covers := func(pending Packet) bool {
+		return feedbackCovers(pending, feedback)
+	}
+
+	return slices.ContainsFunc(s.pendingEarly, covers) || slices.ContainsFunc(s.pendingRegular, covers)
+}
+
+// feedbackCovers reports whether the feedback packet a carries all the
+// information in b, regardless of the member that sent it. NACKs cover
+// each other if a requests all the packets b requests, other feedback
+// packets if they are of the same type and about the same media sources.
+func feedbackCovers(a, b Packet) bool {
+	if nackA, ok := a.(*TransportLayerNack); ok {
+		nackB, ok := b.(*TransportLayerNack)
+		if !ok || nackA.MediaSSRC != nackB.MediaSSRC {
+			return false
+		}
+
+		requested := map[uint16]bool{}
+		for _, pair := range nackA.Nacks {
+			for _, seq := range pair.PacketList() {
+				requested[seq] = true
+			}
+		}
+		for _, pair := range nackB.Nacks {
+			for _, seq := range pair.PacketList() {
+				if !requested[seq] {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && slices.Equal(a.DestinationSSRC(), b.DestinationSSRC())
+}
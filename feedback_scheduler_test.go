@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFeedbackScheduler(config FeedbackSchedulerConfig, members int, now time.Time) *FeedbackScheduler {
+	// The regular interval is 5 seconds with 10 members, one of them sending
+	config.SessionBandwidth = 38400
+	config.InitialPacketSize = 100
+	config.Random = noRandomization
+
+	scheduler := NewFeedbackScheduler(config, now)
+	scheduler.Calculator().SetMembers(members, 1)
+	scheduler.RegularSent(100, now)
+
+	return scheduler
+}
+
+func TestFeedbackSchedulerImmediateMode(t *testing.T) {
+	now := time.Unix(1000, 0)
+	scheduler := newTestFeedbackScheduler(FeedbackSchedulerConfig{}, 2, now)
+	assert.Equal(t, ImmediateFeedbackMode, scheduler.Mode())
+	interval := scheduler.Calculator().Interval()
+
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	decision, due := scheduler.Schedule(pli, now)
+	assert.Equal(t, FeedbackSendEarly, decision)
+	assert.Equal(t, now, due)
+
+	decision, _ = scheduler.Schedule(&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}, now)
+	assert.Equal(t, FeedbackSuppress, decision, "already pending")
+
+	feedback, ok := scheduler.EarlyFeedback(now)
+	assert.True(t, ok)
+	assert.Equal(t, []Packet{pli}, feedback)
+	scheduler.EarlySent(100)
+
+	// The next regular packet is delayed, and no other early packet is allowed
+	regular := now.Add(2 * interval)
+	assert.Equal(t, regular, scheduler.NextRegular())
+	now = now.Add(10 * time.Millisecond)
+	nack := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers([]uint16{10})}
+	decision, due = scheduler.Schedule(nack, now)
+	assert.Equal(t, FeedbackWaitRegular, decision)
+	assert.Equal(t, regular, due)
+
+	feedback, ok = scheduler.RegularFeedback(regular)
+	assert.True(t, ok)
+	assert.Equal(t, []Packet{nack}, feedback)
+	scheduler.RegularSent(100, regular)
+
+	// Early packets are sent without dithering again
+	decision, due = scheduler.Schedule(pli, regular)
+	assert.Equal(t, FeedbackSendEarly, decision)
+	assert.Equal(t, regular, due)
+}
+
+func TestFeedbackSchedulerEarlyMode(t *testing.T) {
+	start := time.Unix(1000, 0)
+	scheduler := newTestFeedbackScheduler(FeedbackSchedulerConfig{}, 10, start)
+	assert.Equal(t, EarlyRTCPMode, scheduler.Mode())
+	regular := scheduler.NextRegular()
+	assert.Equal(t, start.Add(compensated(5*time.Second)), regular)
+
+	// Early feedback is dithered over half the regular interval
+	nack := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers([]uint16{10, 12})}
+	decision, due := scheduler.Schedule(nack, start)
+	assert.Equal(t, FeedbackSendEarly, decision)
+	assert.Equal(t, start.Add(1250*time.Millisecond), due)
+
+	next, ok := scheduler.NextEarly()
+	assert.True(t, ok)
+	assert.Equal(t, due, next)
+
+	// Joins the scheduled early packet
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	decision, due = scheduler.Schedule(pli, start.Add(time.Second))
+	assert.Equal(t, FeedbackSendEarly, decision)
+	assert.Equal(t, next, due)
+
+	_, ok = scheduler.EarlyFeedback(start.Add(time.Second))
+	assert.False(t, ok, "not due yet")
+
+	feedback, ok := scheduler.EarlyFeedback(next)
+	assert.True(t, ok)
+	assert.Equal(t, []Packet{nack, pli}, feedback)
+	scheduler.EarlySent(100)
+
+	// The next regular packet is delayed, and no other early packet is allowed
+	regular = start.Add(2 * compensated(5*time.Second))
+	assert.Equal(t, regular, scheduler.NextRegular())
+	decision, due = scheduler.Schedule(pli, next)
+	assert.Equal(t, FeedbackWaitRegular, decision)
+	assert.Equal(t, regular, due)
+
+	_, ok = scheduler.RegularFeedback(start.Add(compensated(5 * time.Second)))
+	assert.False(t, ok)
+	feedback, ok = scheduler.RegularFeedback(regular)
+	assert.True(t, ok)
+	assert.Equal(t, []Packet{pli}, feedback)
+	scheduler.RegularSent(100, regular)
+
+	// Early packets are allowed again
+	decision, _ = scheduler.Schedule(pli, regular)
+	assert.Equal(t, FeedbackSendEarly, decision)
+}
+
+func TestFeedbackSchedulerCloseToRegular(t *testing.T) {
+	start := time.Unix(1000, 0)
+	scheduler := newTestFeedbackScheduler(FeedbackSchedulerConfig{}, 10, start)
+
+	// The regular packet is sent before the dithering interval ends
+	now := scheduler.NextRegular().Add(-time.Second)
+	decision, due := scheduler.Schedule(&PictureLossIndication{MediaSSRC: 2}, now)
+	assert.Equal(t, FeedbackWaitRegular, decision)
+	assert.Equal(t, scheduler.NextRegular(), due)
+}
+
+func TestFeedbackSchedulerSuppression(t *testing.T) {
+	start := time.Unix(1000, 0)
+	scheduler := newTestFeedbackScheduler(FeedbackSchedulerConfig{}, 10, start)
+
+	nack := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers([]uint16{10, 12})}
+	decision, due := scheduler.Schedule(nack, start)
+	assert.Equal(t, FeedbackSendEarly, decision)
+
+	// Covered by a pending NACK
+	decision, _ = scheduler.Schedule(&TransportLayerNack{
+		SenderSSRC: 1, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers([]uint16{12}),
+	}, start)
+	assert.Equal(t, FeedbackSuppress, decision)
+
+	// Another member's NACK covers only part of it
+	scheduler.Received(&TransportLayerNack{SenderSSRC: 3, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers([]uint16{10})})
+	_, ok := scheduler.NextEarly()
+	assert.True(t, ok)
+
+	scheduler.Received(&TransportLayerNack{
+		SenderSSRC: 3, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers([]uint16{9, 10, 12}),
+	})
+	_, ok = scheduler.EarlyFeedback(due)
+	assert.False(t, ok, "all feedback suppressed")
+
+	// No early packet was sent
+	decision, _ = scheduler.Schedule(&PictureLossIndication{MediaSSRC: 2}, due)
+	assert.Equal(t, FeedbackSendEarly, decision)
+}
+
+func TestFeedbackSchedulerRegularMode(t *testing.T) {
+	start := time.Unix(1000, 0)
+	scheduler := newTestFeedbackScheduler(FeedbackSchedulerConfig{EarlyRTCPMembers: 5}, 10, start)
+	assert.Equal(t, RegularRTCPMode, scheduler.Mode())
+
+	decision, due := scheduler.Schedule(&PictureLossIndication{MediaSSRC: 2}, start)
+	assert.Equal(t, FeedbackWaitRegular, decision)
+	assert.Equal(t, scheduler.NextRegular(), due)
+}
+
+func TestFeedbackSchedulerMinimumRegularInterval(t *testing.T) {
+	start := time.Unix(1000, 0)
+	scheduler := newTestFeedbackScheduler(FeedbackSchedulerConfig{MinimumRegularInterval: 10 * time.Second}, 10, start)
+
+	// Regular packets without feedback are skipped
+	regular := scheduler.NextRegular()
+	_, ok := scheduler.RegularFeedback(regular)
+	assert.False(t, ok)
+	assert.Equal(t, regular.Add(compensated(5*time.Second)), scheduler.NextRegular())
+
+	// Until the minimum interval has elapsed since the last one sent
+	for regular = scheduler.NextRegular(); regular.Before(start.Add(10 * time.Second)); regular = scheduler.NextRegular() {
+		_, ok = scheduler.RegularFeedback(regular)
+		assert.False(t, ok)
+	}
+	_, ok = scheduler.RegularFeedback(regular)
+	assert.True(t, ok)
+	scheduler.RegularSent(100, regular)
+
+	// Unless feedback is pending
+	regular = scheduler.NextRegular()
+	decision, _ := scheduler.Schedule(&PictureLossIndication{MediaSSRC: 2}, regular.Add(-time.Second))
+	assert.Equal(t, FeedbackWaitRegular, decision)
+	feedback, ok := scheduler.RegularFeedback(regular)
+	assert.True(t, ok)
+	assert.Len(t, feedback, 1)
+}
+
+func TestFeedbackSchedulerAVPFMinimum(t *testing.T) {
+	start := time.Unix(1000, 0)
+	scheduler := NewFeedbackScheduler(FeedbackSchedulerConfig{
+		IntervalConfig: IntervalConfig{
+			// 62500 octets per second for RTCP
+			SessionBandwidth:  10000000,
+			InitialPacketSize: 100,
+			Random:            noRandomization,
+		},
+		MinimumRegularInterval: time.Second,
+	}, start)
+	scheduler.Calculator().SetMembers(2, 1)
+
+	// One second before the first regular packet
+	assert.Equal(t, time.Second, scheduler.Calculator().DeterministicInterval())
+
+	// Then no minimum, regular packets without feedback being limited by
+	// T_rr_interval
+	now := start.Add(time.Second)
+	scheduler.RegularSent(100, now)
+	assert.Equal(t, 3200*time.Microsecond, scheduler.Calculator().DeterministicInterval())
+	regular := scheduler.NextRegular()
+	assert.Equal(t, now.Add(compensated(3200*time.Microsecond)), regular)
+
+	_, ok := scheduler.RegularFeedback(regular)
+	assert.False(t, ok)
+	scheduler.Schedule(&PictureLossIndication{MediaSSRC: 2}, regular)
+	_, ok = scheduler.EarlyFeedback(regular)
+	assert.True(t, ok)
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// Keyframe requests for a media source are sent upstream at most once per
+// this window by default.
+const keyframeRequestDefaultWindow = 500 * time.Millisecond
+
+// KeyframeRequestType is the type of packet used to request a keyframe.
+type KeyframeRequestType int
+
+// Keyframe request types.
+const (
+	// KeyframeRequestPLI requests keyframes with PictureLossIndication
+	// packets.
+	KeyframeRequestPLI KeyframeRequestType = iota + 1
+	// KeyframeRequestFIR requests keyframes with FullIntraRequest packets.
+	KeyframeRequestFIR
+)
+
+// KeyframeRequestAggregatorConfig configures a KeyframeRequestAggregator.
+type KeyframeRequestAggregatorConfig struct {
+	// SenderSSRC of the requests sent upstream.
+	SenderSSRC uint32
+	// Window during which requests for a media source are sent upstream at
+	// most once. Zero means 500 milliseconds.
+	Window time.Duration
+	// RequestType used upstream, unless set for the media source with
+	// SetRequestType. Zero means KeyframeRequestPLI.
+	RequestType KeyframeRequestType
+}
+
+type keyframeRequestSource struct {
+	requestType KeyframeRequestType
+	lastSent    time.Time
+	// Whether a keyframe is still expected for the last request sent
+	pending bool
+	// Sequence number of the last FIR sent
+	firSequenceNumber uint8
+	sentFIR           bool
+}
+
+type firRequester struct {
+	senderSSRC uint32
+	mediaSSRC  uint32
+}
+
+// KeyframeRequestAggregator aggregates the PictureLossIndication and
+// FullIntraRequest packets received from many receivers of the same media
+// sources, as in an SFU, into a single request per media source and window,
+// using the request type supported upstream.
+//
+// The sequence number of FullIntraRequest entries follows RFC 5104 section
+// 4.3.1.1: it is incremented for each new request, while requests sent again
+// before KeyframeReceived is called for the media source reuse it. Received
+// FIR entries that repeat the previous sequence number of their sender are
+// ignored.
+//
+// KeyframeRequestAggregator is not safe for concurrent use.
+type KeyframeRequestAggregator struct {
+	config  KeyframeRequestAggregatorConfig
+	sources map[uint32]*keyframeRequestSource
+	// Last FIR sequence number received from each requester
+	received map[firRequester]uint8
+}
+
+// NewKeyframeRequestAggregator returns a KeyframeRequestAggregator with the
+// given configuration.
+func NewKeyframeRequestAggregator(config KeyframeRequestAggregatorConfig) *KeyframeRequestAggregator {
+	if config.Window <= 0 {
+		config.Window = keyframeRequestDefaultWindow
+	}
+	if config.RequestType == 0 {
+		config.RequestType = KeyframeRequestPLI
+	}
+
+	return &KeyframeRequestAggregator{
+		config:   config,
+		sources:  map[uint32]*keyframeRequestSource{},
+		received: map[firRequester]uint8{},
+	}
+}
+
+// SetRequestType sets the request type supported by a media source.
+func (a *KeyframeRequestAggregator) SetRequestType(mediaSSRC uint32, requestType KeyframeRequestType) {
+	a.source(mediaSSRC).requestType = requestType
+}
+
+// KeyframeReceived records that a keyframe was received from a media source,
+// so that the next request is a new one.
+func (a *KeyframeRequestAggregator) KeyframeReceived(mediaSSRC uint32) {
+	if source, ok := a.sources[mediaSSRC]; ok {
+		source.pending = false
+	}
+}
+
+// Receive handles a keyframe request received at the given time, and returns
+// the requests to send upstream: a PictureLossIndication per media source
+// requested with PLI, and a single FullIntraRequest for all the media
+// sources requested with FIR. Other packets are ignored.
+func (a *KeyframeRequestAggregator) Receive(packet Packet, now time.Time) []Packet {
+	var ssrcs []uint32
+	switch packet := packet.(type) {
+	case *PictureLossIndication:
+		ssrcs = append(ssrcs, packet.MediaSSRC)
+	case *FullIntraRequest:
+		for _, entry := range packet.FIR {
+			requester := firRequester{packet.SenderSSRC, entry.SSRC}
+			if previous, ok := a.received[requester]; ok && previous == entry.SequenceNumber {
+				continue
+			}
+			a.received[requester] = entry.SequenceNumber
+			ssrcs = append(ssrcs, entry.SSRC)
+		}
+	}
+
+	var packets []Packet
+	var fir *FullIntraRequest
+	for _, ssrc := range ssrcs {
+		source := a.source(ssrc)
+		if !source.lastSent.IsZero() && now.Sub(source.lastSent) < a.config.Window {
+			continue
+		}
+
+		if source.requestType == KeyframeRequestFIR {
+			if !source.pending || !source.sentFIR {
+				source.firSequenceNumber++
+			}
+			source.sentFIR = true
+			if fir == nil {
+				fir = &FullIntraRequest{SenderSSRC: a.config.SenderSSRC}
+				packets = append(packets, fir)
+			}
+			fir.FIR = append(fir.FIR, FIREntry{SSRC: ssrc, SequenceNumber: source.firSequenceNumber})
+		} else {
+			packets = append(packets, &PictureLossIndication{SenderSSRC: a.config.SenderSSRC, MediaSSRC: ssrc})
+		}
+		source.lastSent = now
+		source.pending = true
+	}
+
+	return packets
+}
+
+func (a *KeyframeRequestAggregator) source(mediaSSRC uint32) *keyframeRequestSource {
+	source, ok := a.sources[mediaSSRC]
+	if !ok {
+		source = &keyframeRequestSource{requestType: a.config.RequestType}
+		a.sources[mediaSSRC] = source
+	}
+
+	return source
+}
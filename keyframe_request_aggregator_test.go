@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyframeRequestAggregator(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	t.Run("PLI", func(t *testing.T) {
+		aggregator := NewKeyframeRequestAggregator(KeyframeRequestAggregatorConfig{SenderSSRC: 1})
+		pli := []Packet{&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}}
+
+		assert.Equal(t, pli, aggregator.Receive(&PictureLossIndication{SenderSSRC: 10, MediaSSRC: 2}, now))
+		assert.Nil(t, aggregator.Receive(&PictureLossIndication{SenderSSRC: 11, MediaSSRC: 2}, now.Add(100*time.Millisecond)))
+		assert.Equal(t, pli, aggregator.Receive(&FullIntraRequest{
+			SenderSSRC: 12,
+			FIR:        []FIREntry{{SSRC: 2, SequenceNumber: 7}},
+		}, now.Add(500*time.Millisecond)))
+
+		assert.Nil(t, aggregator.Receive(&ReceiverReport{SSRC: 10}, now.Add(time.Second)))
+	})
+
+	t.Run("FIR", func(t *testing.T) {
+		aggregator := NewKeyframeRequestAggregator(KeyframeRequestAggregatorConfig{
+			SenderSSRC:  1,
+			Window:      time.Second,
+			RequestType: KeyframeRequestFIR,
+		})
+		aggregator.SetRequestType(4, KeyframeRequestPLI)
+
+		assert.Equal(t, []Packet{
+			&FullIntraRequest{SenderSSRC: 1, FIR: []FIREntry{{SSRC: 2, SequenceNumber: 1}, {SSRC: 3, SequenceNumber: 1}}},
+			&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 4},
+		}, aggregator.Receive(&FullIntraRequest{
+			SenderSSRC: 10,
+			FIR:        []FIREntry{{SSRC: 2, SequenceNumber: 5}, {SSRC: 3, SequenceNumber: 0}, {SSRC: 4, SequenceNumber: 9}},
+		}, now))
+
+		// Repeated requests from a receiver are ignored
+		aggregator.KeyframeReceived(2)
+		assert.Nil(t, aggregator.Receive(&FullIntraRequest{
+			SenderSSRC: 10,
+			FIR:        []FIREntry{{SSRC: 2, SequenceNumber: 5}},
+		}, now.Add(time.Second)))
+
+		// New request after the keyframe, and repetition while it is pending
+		assert.Equal(t, []Packet{
+			&FullIntraRequest{SenderSSRC: 1, FIR: []FIREntry{{SSRC: 2, SequenceNumber: 2}, {SSRC: 3, SequenceNumber: 1}}},
+		}, aggregator.Receive(&FullIntraRequest{
+			SenderSSRC: 10,
+			FIR:        []FIREntry{{SSRC: 2, SequenceNumber: 6}, {SSRC: 3, SequenceNumber: 1}},
+		}, now.Add(time.Second)))
+
+		assert.Equal(t, []Packet{
+			&FullIntraRequest{SenderSSRC: 1, FIR: []FIREntry{{SSRC: 3, SequenceNumber: 1}}},
+		}, aggregator.Receive(&PictureLossIndication{SenderSSRC: 11, MediaSSRC: 3}, now.Add(2*time.Second)))
+	})
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// LossNotification is an application layer feedback message used by
+// libwebrtc (goog-lntf) to tell the sender which frames the receiver was
+// able to decode, so that it can decide whether a keyframe is necessary.
+// It shares FMT 15 with ReceiverEstimatedMaximumBitrate, and is identified
+// by the unique identifier 'LNTF'.
+type LossNotification struct {
+	// SSRC of sender
+	SenderSSRC uint32
+
+	// SSRC of the media stream the notification refers to
+	MediaSSRC uint32
+
+	// Sequence number of the last RTP packet of the last decodable frame
+	LastDecoded uint16
+
+	// Sequence number of the last RTP packet received. It must not be more
+	// than 0x7FFF packets ahead of LastDecoded.
+	LastReceived uint16
+
+	// Whether all the dependencies of the frame LastReceived belongs to
+	// have been received, so that it may be decodable
+	Decodable bool
+}
+
+const (
+	lntfLength           = 4
+	lntfIdentifierOffset = 12
+	lntfSequenceOffset   = 16
+	lntfMaxDelta         = 0x7FFF
+)
+
+func lntfIdentifier() []byte {
+	return []byte{'L', 'N', 'T', 'F'}
+}
+
+// Marshal encodes the LossNotification in binary.
+func (p LossNotification) Marshal() ([]byte, error) {
+	/*
+	 *  0                   1                   2                   3
+	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |V=2|P| FMT=15  |   PT=206      |             length            |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |                  SSRC of packet sender                        |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |                  SSRC of media source                         |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |  Unique identifier 'L' 'N' 'T' 'F'                            |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | Last Decoded Sequence Number  | Last Received SeqNum Delta  |D|
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 */
+	delta := p.LastReceived - p.LastDecoded
+	if delta > lntfMaxDelta {
+		return nil, errInvalidLossNotificationDelta
+	}
+
+	rawPacket := make([]byte, p.MarshalSize())
+	packetBody := rawPacket[headerLength:]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
+	copy(rawPacket[lntfIdentifierOffset:], lntfIdentifier())
+
+	binary.BigEndian.PutUint16(rawPacket[lntfSequenceOffset:], p.LastDecoded)
+	deltaAndFlag := delta << 1
+	if p.Decodable {
+		deltaAndFlag |= 1
+	}
+	binary.BigEndian.PutUint16(rawPacket[lntfSequenceOffset+2:], deltaAndFlag)
+
+	hData, err := p.Header().Marshal()
+	if err != nil {
+		return nil, err
+	}
+	copy(rawPacket, hData)
+
+	return rawPacket, nil
+}
+
+// Unmarshal decodes the LossNotification from binary.
+func (p *LossNotification) Unmarshal(rawPacket []byte) error {
+	if len(rawPacket) < (lntfLength+1)*4 {
+		return errPacketTooShort
+	}
+
+	var h Header
+	if err := h.Unmarshal(rawPacket); err != nil {
+		return err
+	}
+
+	if h.Type != TypePayloadSpecificFeedback || h.Count != FormatREMB {
+		return errWrongType
+	}
+
+	if !bytes.Equal(rawPacket[lntfIdentifierOffset:lntfSequenceOffset], lntfIdentifier()) {
+		return errMissingLNTFIdentifier
+	}
+
+	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
+	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
+
+	p.LastDecoded = binary.BigEndian.Uint16(rawPacket[lntfSequenceOffset:])
+	deltaAndFlag := binary.BigEndian.Uint16(rawPacket[lntfSequenceOffset+2:])
+	p.LastReceived = p.LastDecoded + deltaAndFlag>>1
+	p.Decodable = deltaAndFlag&1 != 0
+
+	return nil
+}
+
+// Header returns the Header associated with this packet.
+func (p *LossNotification) Header() Header {
+	return Header{
+		Count:  FormatREMB,
+		Type:   TypePayloadSpecificFeedback,
+		Length: lntfLength,
+	}
+}
+
+// MarshalSize returns the size of the packet once marshaled.
+func (p *LossNotification) MarshalSize() int {
+	return (lntfLength + 1) * 4
+}
+
+func (p *LossNotification) String() string {
+	return fmt.Sprintf("LossNotification %x %x last decoded %d last received %d decodable %t",
+		p.SenderSSRC, p.MediaSSRC, p.LastDecoded, p.LastReceived, p.Decodable)
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (p *LossNotification) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Packet = (*LossNotification)(nil) // assert is a Packet
+
+func TestLossNotificationUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      LossNotification
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Data: []byte{
+				// v=2, p=0, FMT=15, PSFB, len=4
+				0x8f, 0xce, 0x00, 0x04,
+				// ssrc=0x01
+				0x00, 0x00, 0x00, 0x01,
+				// ssrc=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// 'LNTF'
+				0x4c, 0x4e, 0x54, 0x46,
+				// last decoded=0xfffe, delta=5, decodable
+				0xff, 0xfe, 0x00, 0x0b,
+			},
+			Want: LossNotification{
+				SenderSSRC:   0x01,
+				MediaSSRC:    0x4bc4fcb4,
+				LastDecoded:  0xfffe,
+				LastReceived: 0x0003,
+				Decodable:    true,
+			},
+		},
+		{
+			Name: "packet too short",
+			Data: []byte{
+				0x8f, 0xce, 0x00, 0x03,
+				0x00, 0x00, 0x00, 0x01,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x4c, 0x4e, 0x54, 0x46,
+			},
+			WantError: errPacketTooShort,
+		},
+		{
+			Name: "wrong identifier",
+			Data: []byte{
+				0x8f, 0xce, 0x00, 0x04,
+				0x00, 0x00, 0x00, 0x01,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// 'REMB'
+				0x52, 0x45, 0x4d, 0x42,
+				0xff, 0xfe, 0x00, 0x0b,
+			},
+			WantError: errMissingLNTFIdentifier,
+		},
+		{
+			Name: "wrong type",
+			Data: []byte{
+				// v=2, p=0, FMT=15, TSFB, len=4
+				0x8f, 0xcd, 0x00, 0x04,
+				0x00, 0x00, 0x00, 0x01,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x4c, 0x4e, 0x54, 0x46,
+				0xff, 0xfe, 0x00, 0x0b,
+			},
+			WantError: errWrongType,
+		},
+	} {
+		var lntf LossNotification
+		err := lntf.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		assert.Equalf(t, test.Want, lntf, "Unmarshal %q", test.Name)
+	}
+}
+
+func TestLossNotificationRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Packet    LossNotification
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Packet: LossNotification{
+				SenderSSRC:   1,
+				MediaSSRC:    2,
+				LastDecoded:  100,
+				LastReceived: 120,
+			},
+		},
+		{
+			Name: "wraparound",
+			Packet: LossNotification{
+				SenderSSRC:   5000,
+				MediaSSRC:    6000,
+				LastDecoded:  0xfff0,
+				LastReceived: 0x7fef,
+				Decodable:    true,
+			},
+		},
+		{
+			Name: "delta too large",
+			Packet: LossNotification{
+				LastDecoded:  0,
+				LastReceived: 0x8000,
+			},
+			WantError: errInvalidLossNotificationDelta,
+		},
+	} {
+		data, err := test.Packet.Marshal()
+		assert.ErrorIsf(t, err, test.WantError, "Marshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Lenf(t, data, test.Packet.MarshalSize(), "MarshalSize %q", test.Name)
+
+		packets, err := Unmarshal(data)
+		assert.NoErrorf(t, err, "Unmarshal %q", test.Name)
+		assert.Equalf(t, []Packet{&test.Packet}, packets, "%q lntf round trip mismatch", test.Name)
+	}
+}
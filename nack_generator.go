@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	nackDefaultMaxRetries = 10
+	nackDefaultMaxAge     = time.Second
+	nackDefaultMaxMissing = 1000
+	// Retry interval used until the round-trip time is known.
+	nackDefaultRTT = 100 * time.Millisecond
+)
+
+// NackGeneratorConfig configures a NackGenerator.
+type NackGeneratorConfig struct {
+	// SenderSSRC and MediaSSRC of the TransportLayerNack packets.
+	SenderSSRC uint32
+	MediaSSRC  uint32
+	// MaxRetries is the number of times a missing packet is NACKed before
+	// giving up. Zero means 10.
+	MaxRetries int
+	// MaxAge is the time after which a missing packet is given up. Zero
+	// means one second.
+	MaxAge time.Duration
+	// MaxMissing is the number of missing packets tracked, the oldest ones
+	// being given up first. Zero means 1000.
+	MaxMissing int
+	// Backoff multiplies the interval between NACKs of a packet after each
+	// retry. Values below 1 keep the interval at one round-trip time.
+	Backoff float64
+}
+
+// NackGeneratorStats are the statistics of a NackGenerator.
+type NackGeneratorStats struct {
+	// Missing is the number of packets currently missing.
+	Missing int
+	// Nacked is the number of packets NACKed at least once.
+	Nacked uint64
+	// Retries is the number of NACKs sent for packets already NACKed.
+	Retries uint64
+	// Recovered is the number of NACKed packets received afterwards.
+	Recovered uint64
+	// GivenUp is the number of missing packets that are no longer NACKed.
+	GivenUp uint64
+}
+
+type nackEntry struct {
+	detected time.Time
+	lastSent time.Time
+	interval time.Duration
+	retries  int
+}
+
+// NackGenerator tracks the packets missing from an incoming RTP stream and
+// generates the TransportLayerNack packets requesting them. A missing packet
+// is NACKed as soon as it is detected, and again every round-trip time until
+// it is received, it has been NACKed MaxRetries times or it is older than
+// MaxAge.
+//
+// NackGenerator is not safe for concurrent use.
+type NackGenerator struct {
+	config  NackGeneratorConfig
+	rtt     time.Duration
+	started bool
+	highest int64
+	missing map[int64]*nackEntry
+	stats   NackGeneratorStats
+}
+
+// NewNackGenerator returns a NackGenerator with the given configuration.
+func NewNackGenerator(config NackGeneratorConfig) *NackGenerator {
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = nackDefaultMaxRetries
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = nackDefaultMaxAge
+	}
+	if config.MaxMissing <= 0 {
+		config.MaxMissing = nackDefaultMaxMissing
+	}
+
+	return &NackGenerator{
+		config:  config,
+		rtt:     nackDefaultRTT,
+		missing: map[int64]*nackEntry{},
+	}
+}
+
+// SetRTT sets the round-trip time to the media sender, used as the interval
+// between NACKs of a packet.
+func (g *NackGenerator) SetRTT(rtt time.Duration) {
+	if rtt > 0 {
+		g.rtt = rtt
+	}
+}
+
+// Receive records the RTP packet received with the given sequence number.
+func (g *NackGenerator) Receive(sequenceNumber uint16, now time.Time) {
+	if !g.started {
+		g.started = true
+		g.highest = int64(sequenceNumber)
+
+		return
+	}
+
+	unwrapped := g.highest + int64(int16(sequenceNumber-uint16(g.highest))) //nolint:gosec // G115
+	if unwrapped <= g.highest {
+		if entry, ok := g.missing[unwrapped]; ok {
+			if entry.retries > 0 {
+				g.stats.Recovered++
+			}
+			delete(g.missing, unwrapped)
+		}
+
+		return
+	}
+
+	// Packets that can't be tracked are given up right away
+	first := max(g.highest+1, unwrapped-int64(g.config.MaxMissing))
+	g.stats.GivenUp += uint64(first - g.highest - 1) //nolint:gosec // G115
+	for seq := first; seq < unwrapped; seq++ {
+		g.missing[seq] = &nackEntry{detected: now}
+	}
+	g.highest = unwrapped
+
+	if excess := len(g.missing) - g.config.MaxMissing; excess > 0 {
+		for _, seq := range g.missingSequenceNumbers()[:excess] {
+			delete(g.missing, seq)
+			g.stats.GivenUp++
+		}
+	}
+}
+
+// Nack returns the TransportLayerNack packet for the missing packets due to
+// be NACKed at the given time, or nil if there are none, and records that it
+// is sent. The packet carries at most as many NACK pairs as fit in an RTCP
+// packet, the remaining missing packets are left for the next call.
+func (g *NackGenerator) Nack(now time.Time) *TransportLayerNack {
+	var sequenceNumbers []uint16
+	var pairs int
+	var pairStart int64
+	for _, seq := range g.missingSequenceNumbers() {
+		entry := g.missing[seq]
+		if entry.retries > 0 && now.Sub(entry.lastSent) < entry.interval {
+			continue
+		}
+
+		if entry.retries >= g.config.MaxRetries || now.Sub(entry.detected) > g.config.MaxAge {
+			delete(g.missing, seq)
+			g.stats.GivenUp++
+
+			continue
+		}
+
+		// A NACK pair covers its packet ID and the 16 following packets
+		if pairs == 0 || seq-pairStart > 16 {
+			if pairs == nackMaxPairs {
+				break
+			}
+			pairs++
+			pairStart = seq
+		}
+
+		if entry.retries == 0 {
+			g.stats.Nacked++
+			entry.interval = g.rtt
+		} else {
+			g.stats.Retries++
+			if g.config.Backoff > 1 {
+				entry.interval = time.Duration(float64(entry.interval) * g.config.Backoff)
+			}
+		}
+		entry.interval = max(entry.interval, g.rtt)
+		entry.retries++
+		entry.lastSent = now
+		sequenceNumbers = append(sequenceNumbers, uint16(seq)) //nolint:gosec // G115
+	}
+
+	if len(sequenceNumbers) == 0 {
+		return nil
+	}
+
+	return &TransportLayerNack{
+		SenderSSRC: g.config.SenderSSRC,
+		MediaSSRC:  g.config.MediaSSRC,
+		Nacks:      NackPairsFromSequenceNumbers(sequenceNumbers),
+	}
+}
+
+// Stats returns the statistics of the generator.
+func (g *NackGenerator) Stats() NackGeneratorStats {
+	stats := g.stats
+	stats.Missing = len(g.missing)
+
+	return stats
+}
+
+func (g *NackGenerator) missingSequenceNumbers() []int64 {
+	sequenceNumbers := make([]int64, 0, len(g.missing))
+	for seq := range g.missing {
+		sequenceNumbers = append(sequenceNumbers, seq)
+	}
+	slices.Sort(sequenceNumbers)
+
+	return sequenceNumbers
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nackedSequenceNumbers(nack *TransportLayerNack) []uint16 {
+	if nack == nil {
+		return nil
+	}
+
+	var sequenceNumbers []uint16
+	for _, pair := range nack.Nacks {
+		sequenceNumbers = append(sequenceNumbers, pair.PacketList()...)
+	}
+
+	return sequenceNumbers
+}
+
+func TestNackGenerator(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	t.Run("retries", func(t *testing.T) {
+		generator := NewNackGenerator(NackGeneratorConfig{SenderSSRC: 1, MediaSSRC: 2, MaxRetries: 2})
+		generator.SetRTT(50 * time.Millisecond)
+		assert.Nil(t, generator.Nack(now))
+
+		generator.Receive(65533, now)
+		generator.Receive(1, now)
+		generator.Receive(65535, now)
+		nack := generator.Nack(now)
+		assert.Equal(t, uint32(1), nack.SenderSSRC)
+		assert.Equal(t, uint32(2), nack.MediaSSRC)
+		assert.Equal(t, []uint16{65534, 0}, nackedSequenceNumbers(nack))
+		assert.Nil(t, generator.Nack(now.Add(49*time.Millisecond)))
+
+		generator.Receive(0, now.Add(49*time.Millisecond))
+		assert.Equal(t, []uint16{65534}, nackedSequenceNumbers(generator.Nack(now.Add(50*time.Millisecond))))
+
+		// Given up after MaxRetries
+		assert.Nil(t, generator.Nack(now.Add(100*time.Millisecond)))
+		assert.Equal(t, NackGeneratorStats{Nacked: 2, Retries: 1, Recovered: 1, GivenUp: 1}, generator.Stats())
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		generator := NewNackGenerator(NackGeneratorConfig{Backoff: 2})
+		generator.Receive(10, now)
+		generator.Receive(12, now)
+
+		var sent []time.Duration
+		for elapsed := time.Duration(0); elapsed <= time.Second; elapsed += 10 * time.Millisecond {
+			if generator.Nack(now.Add(elapsed)) != nil {
+				sent = append(sent, elapsed)
+			}
+		}
+		assert.Equal(t, []time.Duration{
+			0, 100 * time.Millisecond, 300 * time.Millisecond, 700 * time.Millisecond,
+		}, sent)
+		assert.Equal(t, 1, generator.Stats().Missing)
+
+		// Given up when too old
+		assert.Nil(t, generator.Nack(now.Add(1500*time.Millisecond)))
+		assert.Equal(t, NackGeneratorStats{Nacked: 1, Retries: 3, GivenUp: 1}, generator.Stats())
+	})
+
+	t.Run("too many missing", func(t *testing.T) {
+		generator := NewNackGenerator(NackGeneratorConfig{MaxMissing: 3})
+		generator.Receive(0, now)
+		generator.Receive(3, now)
+		generator.Receive(6, now)
+		assert.Equal(t, []uint16{2, 4, 5}, nackedSequenceNumbers(generator.Nack(now)))
+
+		generator.Receive(10, now)
+		assert.Equal(t, []uint16{7, 8, 9}, nackedSequenceNumbers(generator.Nack(now)))
+
+		// Given up, reordered and duplicate packets
+		generator.Receive(5, now)
+		generator.Receive(8, now)
+		generator.Receive(8, now)
+		assert.Equal(t, NackGeneratorStats{Missing: 2, Nacked: 6, Recovered: 1, GivenUp: 4}, generator.Stats())
+	})
+
+	t.Run("too many pairs", func(t *testing.T) {
+		// Every gap of 17 missing packets takes a NACK pair
+		generator := NewNackGenerator(NackGeneratorConfig{MaxMissing: 10000})
+		for seq := uint16(0); seq <= 260*18; seq += 18 {
+			generator.Receive(seq, now)
+		}
+
+		nack := generator.Nack(now)
+		assert.Len(t, nack.Nacks, nackMaxPairs)
+		_, err := nack.Marshal()
+		assert.NoError(t, err)
+
+		nack = generator.Nack(now)
+		assert.Len(t, nack.Nacks, 260-nackMaxPairs)
+		assert.Equal(t, uint16(nackMaxPairs*18+1), nack.Nacks[0].PacketID)
+		assert.Equal(t, uint64(260*17), generator.Stats().Nacked)
+	})
+}
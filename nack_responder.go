@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// The retransmission bitrate is measured over this window.
+const nackResponderRateWindow = time.Second
+
+// PacketHistory gives access to the packets sent, for retransmission.
+// SendHistory implements it.
+type PacketHistory interface {
+	// PacketSize returns the size in bytes of the packet sent with the given
+	// SSRC and sequence number, or false if it is not available anymore.
+	PacketSize(ssrc uint32, sequenceNumber uint16) (int, bool)
+}
+
+// NackResponderConfig configures a NackResponder.
+type NackResponderConfig struct {
+	History PacketHistory
+	// MaxBitrate of the retransmissions in bits per second, measured over
+	// one second. Zero means no limit.
+	MaxBitrate uint64
+}
+
+// Retransmission is a packet to retransmit.
+type Retransmission struct {
+	SSRC           uint32
+	SequenceNumber uint16
+	// Size of the packet in bytes.
+	Size int
+}
+
+type sentRetransmission struct {
+	time time.Time
+	size int
+}
+
+// NackResponder selects the packets to retransmit in response to
+// TransportLayerNack packets. Packets requested again less than a round-trip
+// time after being retransmitted are ignored, as the retransmission is
+// still in flight, and packets are dropped when retransmitting them would
+// exceed the bitrate limit.
+//
+// NackResponder is not safe for concurrent use.
+type NackResponder struct {
+	config NackResponderConfig
+	rtt    time.Duration
+	// Last retransmission of the packets retransmitted within a round-trip
+	// time
+	recent map[ssrcSequenceNumber]time.Time
+	// Retransmissions within the rate window, oldest first
+	sent      []sentRetransmission
+	sentBytes int
+}
+
+// NewNackResponder returns a NackResponder with the given configuration.
+func NewNackResponder(config NackResponderConfig) *NackResponder {
+	return &NackResponder{
+		config: config,
+		rtt:    nackDefaultRTT,
+		recent: map[ssrcSequenceNumber]time.Time{},
+	}
+}
+
+// SetRTT sets the round-trip time to the receivers.
+func (r *NackResponder) SetRTT(rtt time.Duration) {
+	if rtt > 0 {
+		r.rtt = rtt
+	}
+}
+
+// Respond returns the packets to retransmit now in response to a
+// TransportLayerNack packet, in the order they are requested, and records
+// that they are retransmitted.
+func (r *NackResponder) Respond(nack *TransportLayerNack, now time.Time) []Retransmission {
+	r.expire(now)
+
+	var retransmissions []Retransmission
+	for _, pair := range nack.Nacks {
+		pair.Range(func(sequenceNumber uint16) bool {
+			key := ssrcSequenceNumber{nack.MediaSSRC, sequenceNumber}
+			if _, ok := r.recent[key]; ok {
+				return true
+			}
+
+			size, ok := r.config.History.PacketSize(nack.MediaSSRC, sequenceNumber)
+			if !ok || !r.allowed(size) {
+				return true
+			}
+
+			r.recent[key] = now
+			r.sent = append(r.sent, sentRetransmission{time: now, size: size})
+			r.sentBytes += size
+			retransmissions = append(retransmissions, Retransmission{
+				SSRC:           nack.MediaSSRC,
+				SequenceNumber: sequenceNumber,
+				Size:           size,
+			})
+
+			return true
+		})
+	}
+
+	return retransmissions
+}
+
+// allowed returns whether retransmitting size more bytes stays within the
+// bitrate limit.
+func (r *NackResponder) allowed(size int) bool {
+	if r.config.MaxBitrate == 0 {
+		return true
+	}
+
+	// The rate window is one second long
+	return uint64(r.sentBytes+size)*8 <= r.config.MaxBitrate //nolint:gosec // G115
+}
+
+// expire forgets the retransmissions older than a round-trip time, and those
+// outside the rate window.
+func (r *NackResponder) expire(now time.Time) {
+	for key, sent := range r.recent {
+		if now.Sub(sent) >= r.rtt {
+			delete(r.recent, key)
+		}
+	}
+
+	expired := 0
+	for _, sent := range r.sent {
+		if now.Sub(sent.time) < nackResponderRateWindow {
+			break
+		}
+		r.sentBytes -= sent.size
+		expired++
+	}
+	r.sent = r.sent[expired:]
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNackResponder(t *testing.T) {
+	now := time.Unix(1000, 0)
+	history := NewSendHistory(0)
+	for seq := range uint16(20) {
+		history.Add(SentPacket{SSRC: 2, SequenceNumber: seq, SendTime: now, Size: 100})
+	}
+	retransmitted := func(retransmissions []Retransmission) []uint16 {
+		var sequenceNumbers []uint16
+		for _, retransmission := range retransmissions {
+			assert.Equal(t, uint32(2), retransmission.SSRC)
+			assert.Equal(t, 100, retransmission.Size)
+			sequenceNumbers = append(sequenceNumbers, retransmission.SequenceNumber)
+		}
+
+		return sequenceNumbers
+	}
+	nack := func(sequenceNumbers ...uint16) *TransportLayerNack {
+		return &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: NackPairsFromSequenceNumbers(sequenceNumbers)}
+	}
+
+	t.Run("deduplicates within RTT", func(t *testing.T) {
+		responder := NewNackResponder(NackResponderConfig{History: history})
+		responder.SetRTT(50 * time.Millisecond)
+
+		// Packets missing from the history can't be retransmitted
+		assert.Equal(t, []uint16{1, 3}, retransmitted(responder.Respond(nack(1, 3, 25), now)))
+		assert.Equal(t, []uint16{4}, retransmitted(responder.Respond(nack(1, 3, 4), now.Add(49*time.Millisecond))))
+		assert.Equal(t, []uint16{1, 3}, retransmitted(responder.Respond(nack(1, 3, 4), now.Add(50*time.Millisecond))))
+
+		assert.Nil(t, responder.Respond(&TransportLayerNack{MediaSSRC: 3, Nacks: []NackPair{{PacketID: 1}}}, now))
+	})
+
+	t.Run("bitrate limit", func(t *testing.T) {
+		responder := NewNackResponder(NackResponderConfig{History: history, MaxBitrate: 8 * 250})
+		assert.Equal(t, []uint16{1, 2}, retransmitted(responder.Respond(nack(1, 2, 3), now)))
+		assert.Nil(t, responder.Respond(nack(3), now.Add(999*time.Millisecond)))
+		assert.Equal(t, []uint16{3, 4}, retransmitted(responder.Respond(nack(3, 4, 5), now.Add(time.Second))))
+	})
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch
+// (1 January 1900) and the Unix epoch (1 January 1970).
+const ntpEpochOffset = 2208988800
+
+// ntpEraLength is the number of seconds after which the 32-bit seconds
+// field of an NTP timestamp wraps around, on 7 February 2036.
+const ntpEraLength = 1 << 32
+
+// ntpTimestamp converts a wallclock time into a 64-bit NTP timestamp.
+func ntpTimestamp(t time.Time) uint64 {
+	seconds := uint64(t.Unix()+ntpEpochOffset) % ntpEraLength //nolint:gosec // G115, wraps into the era
+	fraction := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+
+	return seconds<<32 | fraction
+}
+
+// NTPTime is a 64-bit NTP timestamp, as used in the NTPTime field of a
+// SenderReport and the NTPTimestamp field of a
+// ReceiverReferenceTimeReportBlock. The most significant 32 bits hold the
+// seconds since 1 January 1900, and the least significant 32 bits the
+// fraction of a second.
+//
+// As the seconds wrap around in 2036, timestamps are interpreted as
+// described in RFC 4330 section 3: values with the most significant bit
+// set belong to the era starting in 1900, and values without it to the
+// era starting in 2036. This covers the years 1968 to 2104.
+type NTPTime uint64
+
+// NewNTPTime converts a wallclock time into an NTPTime.
+func NewNTPTime(t time.Time) NTPTime {
+	return NTPTime(ntpTimestamp(t))
+}
+
+// Time converts the NTPTime into a wallclock time.
+func (n NTPTime) Time() time.Time {
+	seconds := int64(n >> 32)
+	if seconds&0x80000000 == 0 {
+		seconds += ntpEraLength
+	}
+	// Round to the nearest nanosecond, so that conversions round-trip
+	nanoseconds := (uint64(n&0xFFFFFFFF)*uint64(time.Second) + 1<<31) >> 32
+
+	return time.Unix(seconds-ntpEpochOffset, int64(nanoseconds)).UTC() //nolint:gosec // G115, less than a second
+}
+
+// Compact returns the middle 32 bits of the NTPTime, as used in the
+// LastSenderReport field of a ReceptionReport and the LastRR field of a
+// DLRRReport.
+func (n NTPTime) Compact() CompactNTPTime {
+	return CompactNTPTime(n >> 16) //nolint:gosec // G115
+}
+
+func (n NTPTime) String() string {
+	return n.Time().Format(time.RFC3339Nano)
+}
+
+// CompactNTPTime is the middle 32 bits of an NTP timestamp, in units of
+// 1/65536 seconds. It wraps around every 65536 seconds (about 18 hours).
+type CompactNTPTime uint32
+
+// NewCompactNTPTime converts a wallclock time into a CompactNTPTime.
+func NewCompactNTPTime(t time.Time) CompactNTPTime {
+	return NewNTPTime(t).Compact()
+}
+
+// Time converts the CompactNTPTime into the wallclock time closest to the
+// given reference time, since the compact form does not carry the most
+// significant bits of the seconds.
+func (c CompactNTPTime) Time(reference time.Time) time.Time {
+	diff := int32(c - NewCompactNTPTime(reference)) //nolint:gosec // G115, signed modular difference
+	offset := time.Duration(diff) * time.Second / 65536
+
+	return reference.Add(offset).Truncate(0)
+}
+
+// Duration converts a delay expressed in units of 1/65536 seconds, such as
+// the Delay field of a ReceptionReport or the DLRR field of a DLRRReport,
+// into a time.Duration.
+func (c CompactNTPTime) Duration() time.Duration {
+	return time.Duration(c) * time.Second / 65536
+}
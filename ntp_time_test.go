@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNTPTimestamp(t *testing.T) {
+	assert.Equal(t, uint64(0x83AA7E8000000000), ntpTimestamp(time.Unix(0, 0)))
+	assert.Equal(t, uint64(0x83AA7E8080000000), ntpTimestamp(time.Unix(0, 500000000)))
+}
+
+func TestNTPTime(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Time time.Time
+		NTP  NTPTime
+	}{
+		{
+			Name: "unix epoch",
+			Time: time.Unix(0, 0).UTC(),
+			NTP:  0x83AA7E8000000000,
+		},
+		{
+			Name: "half second",
+			Time: time.Unix(0, 500000000).UTC(),
+			NTP:  0x83AA7E8080000000,
+		},
+		{
+			Name: "last second of era 0",
+			Time: time.Date(2036, 2, 7, 6, 28, 15, 0, time.UTC),
+			NTP:  0xFFFFFFFF00000000,
+		},
+		{
+			Name: "start of era 1",
+			Time: time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC),
+			NTP:  0x0000000000000000,
+		},
+		{
+			Name: "era 1",
+			Time: time.Date(2040, 1, 1, 0, 0, 0, 123456789, time.UTC),
+			NTP:  NTPTime(uint64(0x0754FD00)<<32 | 0x1F9ADD37),
+		},
+	} {
+		assert.Equalf(t, test.NTP, NewNTPTime(test.Time), "NewNTPTime %q", test.Name)
+		assert.Equalf(t, test.Time, test.NTP.Time(), "Time %q", test.Name)
+	}
+
+	ntp := NTPTime(0xda8bd1fcdddda05a)
+	assert.Equal(t, CompactNTPTime(0xd1fcdddd), ntp.Compact())
+	assert.Equal(t, "2016-03-10T10:59:08.866663Z", ntp.String())
+}
+
+func TestCompactNTPTime(t *testing.T) {
+	reference := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, offset := range []time.Duration{0, 5 * time.Second, -5 * time.Second, 9 * time.Hour, -9 * time.Hour} {
+		compact := NewCompactNTPTime(reference.Add(offset))
+		assert.WithinDurationf(t, reference.Add(offset), compact.Time(reference), 20*time.Microsecond, "offset %v", offset)
+	}
+
+	assert.Equal(t, 1500*time.Millisecond, CompactNTPTime(0x18000).Duration())
+}
+
+func TestSenderReportStringNTPTime(t *testing.T) {
+	report := SenderReport{SSRC: 0x902f9e2e, NTPTime: 0xda8bd1fcdddda05a}
+	assert.Contains(t, report.String(), "NTPTime:\t15747911406015324250 (2016-03-10T10:59:08.866663Z)\n")
+}
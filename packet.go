@@ -3,6 +3,11 @@
 
 package rtcp
 
+import (
+	"bytes"
+	"sync"
+)
+
 // Packet represents an RTCP packet, a protocol used for out-of-band statistics
 // and control information for an RTP session.
 type Packet interface {
@@ -58,8 +63,6 @@ func Marshal(packets []Packet) ([]byte, error) {
 
 // unmarshal is a factory which pulls the first RTCP packet from a bytestream,
 // and returns it's parsed representation, and the amount of data that was processed.
-//
-//nolint:cyclop
 func unmarshal(rawData []byte) (packet Packet, bytesprocessed int, err error) {
 	var header Header
 
@@ -74,58 +77,205 @@ func unmarshal(rawData []byte) (packet Packet, bytesprocessed int, err error) {
 	}
 	inPacket := rawData[:bytesprocessed]
 
+	packet = newPacket(header, inPacket)
+	err = packet.Unmarshal(inPacket)
+
+	return packet, bytesprocessed, err
+}
+
+// newPacket returns an empty packet of the type described by header.
+// Packets without a built-in or registered implementation are decoded
+// as RawPacket.
+func newPacket(header Header, inPacket []byte) Packet {
+	if packet := newBuiltinPacket(header, inPacket); packet != nil {
+		return packet
+	}
+
+	packetRegistry.RLock()
+	newRegisteredPacket, ok := packetRegistry.packets[packetRegistryKeyOf(header, inPacket)]
+	packetRegistry.RUnlock()
+	if ok {
+		return newRegisteredPacket()
+	}
+
+	return new(RawPacket)
+}
+
+// newBuiltinPacket returns an empty packet of the type described by header
+// if it is implemented by this package, or nil otherwise.
+//
+//nolint:cyclop
+func newBuiltinPacket(header Header, inPacket []byte) Packet {
 	switch header.Type {
 	case TypeSenderReport:
-		packet = new(SenderReport)
+		return new(SenderReport)
 
 	case TypeReceiverReport:
-		packet = new(ReceiverReport)
+		return new(ReceiverReport)
 
 	case TypeSourceDescription:
-		packet = new(SourceDescription)
+		return new(SourceDescription)
 
 	case TypeGoodbye:
-		packet = new(Goodbye)
+		return new(Goodbye)
 
 	case TypeTransportSpecificFeedback:
 		switch header.Count {
 		case FormatTLN:
-			packet = new(TransportLayerNack)
+			return new(TransportLayerNack)
 		case FormatRRR:
-			packet = new(RapidResynchronizationRequest)
+			return new(RapidResynchronizationRequest)
 		case FormatTCC:
-			packet = new(TransportLayerCC)
+			return new(TransportLayerCC)
 		case FormatCCFB:
-			packet = new(CCFeedbackReport)
-		default:
-			packet = new(RawPacket)
+			return new(CCFeedbackReport)
 		}
 
 	case TypePayloadSpecificFeedback:
 		switch header.Count {
 		case FormatPLI:
-			packet = new(PictureLossIndication)
+			return new(PictureLossIndication)
 		case FormatSLI:
-			packet = new(SliceLossIndication)
+			return new(SliceLossIndication)
 		case FormatREMB:
-			packet = new(ReceiverEstimatedMaximumBitrate)
+			// Application layer feedback messages share FMT 15, and are
+			// told apart by the unique identifier following the media SSRC.
+			if len(inPacket) < 16 {
+				break
+			}
+			switch {
+			case bytes.Equal(inPacket[12:16], []byte{'R', 'E', 'M', 'B'}):
+				return new(ReceiverEstimatedMaximumBitrate)
+			case bytes.Equal(inPacket[12:16], lntfIdentifier()):
+				return new(LossNotification)
+			}
 		case FormatFIR:
-			packet = new(FullIntraRequest)
-		default:
-			packet = new(RawPacket)
+			return new(FullIntraRequest)
 		}
 
 	case TypeExtendedReport:
-		packet = new(ExtendedReport)
+		return new(ExtendedReport)
 
 	case TypeApplicationDefined:
-		packet = new(ApplicationDefined)
+		return new(ApplicationDefined)
+	}
 
-	default:
-		packet = new(RawPacket)
+	return nil
+}
+
+type packetRegistryKey struct {
+	Type       PacketType
+	Format     uint8
+	Identifier [4]byte
+}
+
+// newPacketRegistryKey builds the key a packet is registered under. The
+// count field only identifies the message for feedback packets, so it is
+// ignored for all other packet types.
+func newPacketRegistryKey(packetType PacketType, format uint8) packetRegistryKey {
+	if packetType != TypeTransportSpecificFeedback && packetType != TypePayloadSpecificFeedback {
+		format = 0
 	}
 
-	err = packet.Unmarshal(inPacket)
+	return packetRegistryKey{Type: packetType, Format: format}
+}
 
-	return packet, bytesprocessed, err
+// packetRegistryKeyOf returns the key a received packet is looked up with.
+// Application layer feedback messages are also keyed by their unique
+// identifier.
+func packetRegistryKeyOf(header Header, inPacket []byte) packetRegistryKey {
+	key := newPacketRegistryKey(header.Type, header.Count)
+	if isApplicationLayerFeedback(header.Type, header.Count) && len(inPacket) >= 16 {
+		copy(key.Identifier[:], inPacket[12:16])
+	}
+
+	return key
+}
+
+func isApplicationLayerFeedback(packetType PacketType, format uint8) bool {
+	return packetType == TypePayloadSpecificFeedback && format == FormatREMB
+}
+
+//nolint:gochecknoglobals
+var packetRegistry = struct {
+	sync.RWMutex
+	packets map[packetRegistryKey]func() Packet
+}{packets: map[packetRegistryKey]func() Packet{}}
+
+// RegisterPacket registers a constructor for packets of the given type and
+// feedback message type (FMT), so that Unmarshal and CompoundPacket.Unmarshal
+// decode them into the returned Packet instead of a RawPacket. The
+// constructor must return a new, empty packet on every call, whose Unmarshal
+// method accepts the whole RTCP packet including its header.
+//
+// The format is only meaningful for TypeTransportSpecificFeedback and
+// TypePayloadSpecificFeedback packets, and is ignored for all other types.
+// Payload-specific application layer feedback (FMT 15) is told apart by its
+// unique identifier, and must be registered with
+// RegisterApplicationLayerFeedback instead. Packet types and formats
+// implemented by this package cannot be overridden, and each combination
+// may only be registered once.
+func RegisterPacket(packetType PacketType, format uint8, newRegisteredPacket func() Packet) error {
+	if isApplicationLayerFeedback(packetType, format) {
+		return errFeedbackIdentifierRequired
+	}
+	if newBuiltinPacket(Header{Type: packetType, Count: format}, nil) != nil {
+		return errPacketTypeRegistered
+	}
+
+	return registerPacket(newPacketRegistryKey(packetType, format), newRegisteredPacket)
+}
+
+// UnregisterPacket removes a constructor previously added with
+// RegisterPacket. Packets of this type will be decoded as RawPacket again.
+func UnregisterPacket(packetType PacketType, format uint8) {
+	unregisterPacket(newPacketRegistryKey(packetType, format))
+}
+
+// RegisterApplicationLayerFeedback registers a constructor for
+// payload-specific application layer feedback (FMT 15) with the given
+// unique identifier, found right after the media source SSRC, such as
+// "REMB". It otherwise behaves like RegisterPacket. The identifiers of
+// ReceiverEstimatedMaximumBitrate and LossNotification cannot be
+// overridden.
+func RegisterApplicationLayerFeedback(identifier [4]byte, newRegisteredPacket func() Packet) error {
+	header := Header{Type: TypePayloadSpecificFeedback, Count: FormatREMB}
+	inPacket := make([]byte, 16)
+	copy(inPacket[12:], identifier[:])
+	if newBuiltinPacket(header, inPacket) != nil {
+		return errPacketTypeRegistered
+	}
+
+	return registerPacket(packetRegistryKeyOf(header, inPacket), newRegisteredPacket)
+}
+
+// UnregisterApplicationLayerFeedback removes a constructor previously added
+// with RegisterApplicationLayerFeedback.
+func UnregisterApplicationLayerFeedback(identifier [4]byte) {
+	key := newPacketRegistryKey(TypePayloadSpecificFeedback, FormatREMB)
+	key.Identifier = identifier
+	unregisterPacket(key)
+}
+
+func registerPacket(key packetRegistryKey, newRegisteredPacket func() Packet) error {
+	if newRegisteredPacket == nil {
+		return errNilPacketConstructor
+	}
+
+	packetRegistry.Lock()
+	defer packetRegistry.Unlock()
+
+	if _, ok := packetRegistry.packets[key]; ok {
+		return errPacketTypeRegistered
+	}
+	packetRegistry.packets[key] = newRegisteredPacket
+
+	return nil
+}
+
+func unregisterPacket(key packetRegistryKey) {
+	packetRegistry.Lock()
+	defer packetRegistry.Unlock()
+
+	delete(packetRegistry.packets, key)
 }
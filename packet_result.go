@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// PacketResult is the feedback about a single RTP packet, independent of the
+// format it was received in.
+//
+// Arrival times are on the receiver's clock, relative to an arbitrary origin
+// that depends on the feedback format, so that only differences between
+// arrival times reported by the same receiver are meaningful. Each format
+// has its own granularity: TransportLayerCC reports arrival times in units
+// of 250 µs, and CCFeedbackReport in units of 1/1024 s (about 977 µs).
+// Converting from TransportLayerCC to CCFeedbackReport loses precision, and
+// arrival times converted from CCFeedbackReport are rounded down to the
+// TransportLayerCC granularity.
+type PacketResult struct {
+	// MediaSSRC is the SSRC the feedback is about. For TransportLayerCC, it is
+	// the MediaSSRC of the feedback packet.
+	MediaSSRC uint32
+	// SequenceNumber is the transport-wide sequence number for
+	// TransportLayerCC, and the RTP sequence number for CCFeedbackReport.
+	SequenceNumber uint16
+	// Received is false if the packet was lost.
+	Received bool
+	// ArrivalTime of a received packet.
+	ArrivalTime time.Duration
+	// ArrivalTimeUnknown is true if the packet was received but its arrival
+	// time is not reported, or is too old to be reported by a
+	// CCFeedbackReport.
+	ArrivalTimeUnknown bool
+	// ECN bits of the packet, only reported by CCFeedbackReport.
+	ECN ECN
+}
+
+// PacketResultsFromTransportLayerCC returns the results of the packets
+// reported by a TransportLayerCC packet. Arrival times are relative to a
+// reference time of zero.
+func PacketResultsFromTransportLayerCC(packet *TransportLayerCC) ([]PacketResult, error) {
+	arrivals, err := packet.Arrivals()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PacketResult, len(arrivals))
+	for i, arrival := range arrivals {
+		results[i] = PacketResult{
+			MediaSSRC:          packet.MediaSSRC,
+			SequenceNumber:     arrival.SequenceNumber,
+			Received:           arrival.Received,
+			ArrivalTime:        arrival.ArrivalTime,
+			ArrivalTimeUnknown: arrival.WithoutDelta,
+		}
+	}
+
+	return results, nil
+}
+
+// PacketResultsFromCCFeedbackReport returns the results of the packets
+// reported by a CCFeedbackReport. Arrival times are relative to the last
+// wrap around of the compact NTP report timestamp.
+func PacketResultsFromCCFeedbackReport(packet *CCFeedbackReport) []PacketResult {
+	reportTime := CompactNTPTime(packet.ReportTimestamp).Duration()
+
+	var results []PacketResult
+	for _, block := range packet.ReportBlocks {
+		for i, metric := range block.MetricBlocks {
+			result := PacketResult{
+				MediaSSRC:      block.MediaSSRC,
+				SequenceNumber: block.BeginSequence + uint16(i), //nolint:gosec // G115
+				Received:       metric.Received,
+			}
+			if metric.Received {
+				result.ECN = metric.ECN
+				if metric.ArrivalTimeOffset >= ccfbOffsetOverRange {
+					result.ArrivalTimeUnknown = true
+				} else {
+					result.ArrivalTime = reportTime - time.Duration(metric.ArrivalTimeOffset)*time.Second/ccfbOffsetUnits
+				}
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// BuildFromPacketResults returns the TransportLayerCC packets reporting the
+// given results, as Build does. The results must carry transport-wide
+// sequence numbers, and their MediaSSRC and ECN are ignored.
+func (b *TransportLayerCCBuilder) BuildFromPacketResults(results []PacketResult) ([]*TransportLayerCC, error) {
+	arrivals := make([]TransportLayerCCArrival, len(results))
+	for i, result := range results {
+		arrivals[i] = TransportLayerCCArrival{
+			SequenceNumber: result.SequenceNumber,
+			Received:       result.Received,
+			ArrivalTime:    result.ArrivalTime,
+			WithoutDelta:   result.ArrivalTimeUnknown,
+		}
+	}
+
+	return b.Build(arrivals)
+}
+
+// CCFeedbackReportsFromPacketResults returns the CCFeedbackReport packets
+// sent by senderSSRC reporting the given results, with packets of at most
+// maxSize octets as for NewCCFeedbackGenerator. The report timestamp is the
+// latest arrival time, rounded up to the report granularity, so that
+// arrival times are rounded up by less than 1/1024 s. Arrival times more
+// than about 8 s before the latest one are reported as over range.
+//
+// The results must carry the SSRC and RTP sequence number of each packet,
+// as those returned by PacketResultsFromCCFeedbackReport do. Results
+// returned by PacketResultsFromTransportLayerCC carry transport-wide
+// sequence numbers instead: they must first be joined with the sent
+// packets, for example as a SendHistory does, and their MediaSSRC and
+// SequenceNumber replaced with those of each SentPacket.
+func CCFeedbackReportsFromPacketResults(
+	senderSSRC uint32,
+	results []PacketResult,
+	maxSize int,
+) ([]*CCFeedbackReport, error) {
+	var latest time.Duration
+	first := true
+	for _, result := range results {
+		if result.Received && !result.ArrivalTimeUnknown && (first || result.ArrivalTime > latest) {
+			latest = result.ArrivalTime
+			first = false
+		}
+	}
+
+	// Arrival times are placed after the NTP epoch, so that the compact NTP
+	// report timestamp is the arrival time clock
+	epoch := time.Unix(-ntpEpochOffset, 0)
+	units := -floorDiv(-int64(latest)*ccfbOffsetUnits, int64(time.Second))
+	reportTime := epoch.Add(time.Duration(-floorDiv(-units*int64(time.Second), ccfbOffsetUnits)))
+
+	generator := NewCCFeedbackGenerator(senderSSRC, maxSize)
+	for _, result := range results {
+		arrival := ccfbArrival{lost: !result.Received, ecn: result.ECN}
+		if result.Received && !result.ArrivalTimeUnknown {
+			arrival.arrival = epoch.Add(result.ArrivalTime)
+		}
+		generator.add(result.MediaSSRC, result.SequenceNumber, arrival)
+	}
+
+	return generator.BuildReports(reportTime)
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPacketResults(t *testing.T) {
+	builder := NewTransportLayerCCBuilder(1, 2, 0)
+	twcc, err := builder.Build([]TransportLayerCCArrival{
+		{SequenceNumber: 65535, Received: true, ArrivalTime: time.Second + 250*time.Microsecond},
+		{SequenceNumber: 0},
+		{SequenceNumber: 1, Received: true, WithoutDelta: true},
+		{SequenceNumber: 2, Received: true, ArrivalTime: time.Second + 20*time.Millisecond},
+		{SequenceNumber: 3, Received: true, ArrivalTime: time.Second - 5*time.Millisecond},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, twcc, 1)
+
+	results, err := PacketResultsFromTransportLayerCC(twcc[0])
+	assert.NoError(t, err)
+	assert.Equal(t, []PacketResult{
+		{MediaSSRC: 2, SequenceNumber: 65535, Received: true, ArrivalTime: time.Second + 250*time.Microsecond},
+		{MediaSSRC: 2, SequenceNumber: 0},
+		{MediaSSRC: 2, SequenceNumber: 1, Received: true, ArrivalTimeUnknown: true},
+		{MediaSSRC: 2, SequenceNumber: 2, Received: true, ArrivalTime: time.Second + 20*time.Millisecond},
+		{MediaSSRC: 2, SequenceNumber: 3, Received: true, ArrivalTime: time.Second - 5*time.Millisecond},
+	}, results)
+
+	// Stands in for the results joined with the send history
+	ccfb, err := CCFeedbackReportsFromPacketResults(1, results, 0)
+	assert.NoError(t, err)
+	assert.Len(t, ccfb, 1)
+	assert.Len(t, ccfb[0].ReportBlocks, 1)
+	assert.Equal(t, uint16(65535), ccfb[0].ReportBlocks[0].BeginSequence)
+
+	converted := PacketResultsFromCCFeedbackReport(ccfb[0])
+	assert.Len(t, converted, len(results))
+	for i, result := range converted {
+		assert.Equal(t, results[i].MediaSSRC, result.MediaSSRC)
+		assert.Equal(t, results[i].SequenceNumber, result.SequenceNumber)
+		assert.Equal(t, results[i].Received, result.Received)
+		assert.Equal(t, results[i].ArrivalTimeUnknown, result.ArrivalTimeUnknown)
+	}
+
+	// Arrival times keep their differences, with the report granularity
+	origin := converted[3].ArrivalTime - results[3].ArrivalTime
+	for i, result := range converted {
+		if result.Received && !result.ArrivalTimeUnknown {
+			assert.InDelta(t, results[i].ArrivalTime, result.ArrivalTime-origin, float64(time.Second/1024))
+		}
+	}
+
+	twcc, err = builder.BuildFromPacketResults(converted)
+	assert.NoError(t, err)
+	assert.Len(t, twcc, 1)
+	assert.Equal(t, uint8(1), twcc[0].FbPktCount)
+	roundTrip, err := PacketResultsFromTransportLayerCC(twcc[0])
+	assert.NoError(t, err)
+	for i, result := range roundTrip {
+		assert.Equal(t, converted[i].SequenceNumber, result.SequenceNumber)
+		assert.Equal(t, converted[i].Received, result.Received)
+		assert.Equal(t, converted[i].ArrivalTimeUnknown, result.ArrivalTimeUnknown)
+		if result.Received && !result.ArrivalTimeUnknown {
+			assert.LessOrEqual(t, result.ArrivalTime, converted[i].ArrivalTime)
+			assert.Greater(t, result.ArrivalTime, converted[i].ArrivalTime-250*time.Microsecond)
+		}
+	}
+}
+
+func TestPacketResultsFromCCFeedbackReport(t *testing.T) {
+	report := &CCFeedbackReport{
+		SenderSSRC: 1,
+		ReportBlocks: []CCFeedbackReportBlock{{
+			MediaSSRC:     2,
+			BeginSequence: 65535,
+			MetricBlocks: []CCFeedbackMetricBlock{
+				{Received: true, ECN: ECNCE, ArrivalTimeOffset: 512},
+				{},
+				{Received: true, ECN: ECNECT0, ArrivalTimeOffset: ccfbOffsetOverRange},
+				{Received: true, ArrivalTimeOffset: ccfbOffsetUnavailable},
+			},
+		}},
+		ReportTimestamp: 10 << 16,
+	}
+	assert.Equal(t, []PacketResult{
+		{MediaSSRC: 2, SequenceNumber: 65535, Received: true, ArrivalTime: 9500 * time.Millisecond, ECN: ECNCE},
+		{MediaSSRC: 2, SequenceNumber: 0},
+		{MediaSSRC: 2, SequenceNumber: 1, Received: true, ArrivalTimeUnknown: true, ECN: ECNECT0},
+		{MediaSSRC: 2, SequenceNumber: 2, Received: true, ArrivalTimeUnknown: true},
+	}, PacketResultsFromCCFeedbackReport(report))
+}
@@ -137,3 +137,150 @@ func TestInvalidHeaderLength(t *testing.T) {
 	_, err := Unmarshal(invalidPacket)
 	assert.ErrorIs(t, err, errPacketTooShort)
 }
+
+// experimentalFeedback is a minimal feedback packet used to exercise
+// the packet registry.
+type experimentalFeedback struct {
+	Type       PacketType
+	Format     uint8
+	SenderSSRC uint32
+	MediaSSRC  uint32
+}
+
+func (p *experimentalFeedback) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+func (p *experimentalFeedback) Marshal() ([]byte, error) {
+	return []byte{
+		0x80 | p.Format, byte(p.Type), 0x00, 0x03,
+		byte(p.SenderSSRC >> 24), byte(p.SenderSSRC >> 16), byte(p.SenderSSRC >> 8), byte(p.SenderSSRC),
+		byte(p.MediaSSRC >> 24), byte(p.MediaSSRC >> 16), byte(p.MediaSSRC >> 8), byte(p.MediaSSRC),
+		'E', 'X', 'P', 'T',
+	}, nil
+}
+
+func (p *experimentalFeedback) Unmarshal(rawPacket []byte) error {
+	if len(rawPacket) < 16 {
+		return errPacketTooShort
+	}
+	p.Type = PacketType(rawPacket[1])
+	p.Format = rawPacket[0] & 0x1F
+	p.SenderSSRC = uint32(rawPacket[4])<<24 | uint32(rawPacket[5])<<16 | uint32(rawPacket[6])<<8 | uint32(rawPacket[7])
+	p.MediaSSRC = uint32(rawPacket[8])<<24 | uint32(rawPacket[9])<<16 | uint32(rawPacket[10])<<8 | uint32(rawPacket[11])
+
+	return nil
+}
+
+func (p *experimentalFeedback) MarshalSize() int {
+	return 16
+}
+
+func TestRegisterPacket(t *testing.T) {
+	experimental := []byte{
+		// v=2, p=0, FMT=20, RTPFB, len=3
+		0x94, 0xcd, 0x00, 0x03,
+		// sender=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// media=0x4baae1ab
+		0x4b, 0xaa, 0xe1, 0xab,
+		// FCI 'EXPT'
+		0x45, 0x58, 0x50, 0x54,
+	}
+	compound := append(append([]byte{}, realPacket()[:84]...), experimental...)
+
+	// Unregistered feedback is left undecoded
+	packets, err := Unmarshal(experimental)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{(*RawPacket)(&experimental)}, packets)
+
+	assert.NoError(t, RegisterPacket(TypeTransportSpecificFeedback, 20, func() Packet {
+		return new(experimentalFeedback)
+	}))
+	defer UnregisterPacket(TypeTransportSpecificFeedback, 20)
+
+	assert.ErrorIs(t, RegisterPacket(TypeTransportSpecificFeedback, 20, func() Packet {
+		return new(experimentalFeedback)
+	}), errPacketTypeRegistered)
+	assert.ErrorIs(t, RegisterPacket(TypeTransportSpecificFeedback, FormatTLN, func() Packet {
+		return new(experimentalFeedback)
+	}), errPacketTypeRegistered)
+	assert.ErrorIs(t, RegisterPacket(TypePayloadSpecificFeedback, FormatREMB, func() Packet {
+		return new(experimentalFeedback)
+	}), errFeedbackIdentifierRequired)
+	assert.ErrorIs(t, RegisterPacket(TypeSenderReport, 0, func() Packet {
+		return new(experimentalFeedback)
+	}), errPacketTypeRegistered)
+	assert.ErrorIs(t, RegisterPacket(TypeTransportSpecificFeedback, 21, nil), errNilPacketConstructor)
+
+	expected := &experimentalFeedback{
+		Type: TypeTransportSpecificFeedback, Format: 20, SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4baae1ab,
+	}
+	packets, err = Unmarshal(experimental)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{expected}, packets)
+
+	var compoundPacket CompoundPacket
+	assert.NoError(t, compoundPacket.Unmarshal(compound))
+	assert.Len(t, compoundPacket, 3)
+	assert.Equal(t, expected, compoundPacket[2])
+
+	rawPacket, err := compoundPacket.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, compound, rawPacket)
+}
+
+func TestRegisterApplicationLayerFeedback(t *testing.T) {
+	experimental := []byte{
+		// v=2, p=0, FMT=15, PSFB, len=3
+		0x8f, 0xce, 0x00, 0x03,
+		// sender=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// media=0x4baae1ab
+		0x4b, 0xaa, 0xe1, 0xab,
+		// unique identifier 'EXPT'
+		0x45, 0x58, 0x50, 0x54,
+	}
+
+	// Unregistered application layer feedback is left undecoded
+	packets, err := Unmarshal(experimental)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{(*RawPacket)(&experimental)}, packets)
+
+	assert.NoError(t, RegisterApplicationLayerFeedback([4]byte{'E', 'X', 'P', 'T'}, func() Packet {
+		return new(experimentalFeedback)
+	}))
+	defer UnregisterApplicationLayerFeedback([4]byte{'E', 'X', 'P', 'T'})
+
+	assert.ErrorIs(t, RegisterApplicationLayerFeedback([4]byte{'E', 'X', 'P', 'T'}, func() Packet {
+		return new(experimentalFeedback)
+	}), errPacketTypeRegistered)
+	assert.ErrorIs(t, RegisterApplicationLayerFeedback([4]byte{'R', 'E', 'M', 'B'}, func() Packet {
+		return new(experimentalFeedback)
+	}), errPacketTypeRegistered)
+	assert.ErrorIs(t, RegisterApplicationLayerFeedback([4]byte{'L', 'N', 'T', 'F'}, func() Packet {
+		return new(experimentalFeedback)
+	}), errPacketTypeRegistered)
+	assert.ErrorIs(t, RegisterApplicationLayerFeedback([4]byte{'X', 'X', 'X', 'X'}, nil), errNilPacketConstructor)
+
+	expected := &experimentalFeedback{
+		Type: TypePayloadSpecificFeedback, Format: FormatREMB, SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4baae1ab,
+	}
+	packets, err = Unmarshal(experimental)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{expected}, packets)
+
+	// Application layer feedback with another identifier is left undecoded
+	other := append([]byte{}, experimental...)
+	copy(other[12:], "OTHR")
+	packets, err = Unmarshal(other)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{(*RawPacket)(&other)}, packets)
+
+	// REMB is still decoded by the built-in implementation
+	remb, err := (&ReceiverEstimatedMaximumBitrate{SenderSSRC: 1, Bitrate: 8927168, SSRCs: []uint32{1215622422}}).Marshal()
+	assert.NoError(t, err)
+	packets, err = Unmarshal(remb)
+	assert.NoError(t, err)
+	assert.IsType(t, &ReceiverEstimatedMaximumBitrate{}, packets[0])
+}
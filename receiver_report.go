@@ -6,6 +6,7 @@ package rtcp
 import (
 	"encoding/binary"
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -29,6 +30,38 @@ const (
 	rrReportOffset = rrSSRCOffset + ssrcLength
 )
 
+// SplitReports returns the given SenderReport or ReceiverReport with at most
+// 31 reception reports, followed by as many ReceiverReport packets from the
+// same source as needed to carry the remaining ones, as allowed by RFC 3550
+// section 6.4.2. The given report is not modified, and the returned packets
+// don't share their reception reports with it. The returned packets should
+// be sent in the same compound packet.
+func SplitReports(report Packet) ([]Packet, error) {
+	var ssrc uint32
+	var reports []ReceptionReport
+	var packets []Packet
+	switch report := report.(type) {
+	case *SenderReport:
+		first := *report
+		first.Reports = slices.Clone(report.Reports[:min(len(report.Reports), countMax)])
+		ssrc, reports, packets = report.SSRC, report.Reports[len(first.Reports):], []Packet{&first}
+	case *ReceiverReport:
+		first := *report
+		first.Reports = slices.Clone(report.Reports[:min(len(report.Reports), countMax)])
+		ssrc, reports, packets = report.SSRC, report.Reports[len(first.Reports):], []Packet{&first}
+	default:
+		return nil, errBadFirstPacket
+	}
+
+	for len(reports) > 0 {
+		count := min(len(reports), countMax)
+		packets = append(packets, &ReceiverReport{SSRC: ssrc, Reports: slices.Clone(reports[:count])})
+		reports = reports[count:]
+	}
+
+	return packets, nil
+}
+
 // Marshal encodes the ReceiverReport in binary.
 func (r ReceiverReport) Marshal() ([]byte, error) {
 	/*
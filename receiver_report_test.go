@@ -259,3 +259,41 @@ func TestReceiverReportRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Report, decoded, "%s rr round trip mismatch", test.Name)
 	}
 }
+
+func TestSplitReports(t *testing.T) {
+	reports := make([]ReceptionReport, 70)
+	for i := range reports {
+		reports[i].SSRC = uint32(i + 10) //nolint:gosec // G115
+	}
+
+	packets, err := SplitReports(&SenderReport{SSRC: 1, PacketCount: 5, Reports: reports})
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{
+		&SenderReport{SSRC: 1, PacketCount: 5, Reports: reports[:31]},
+		&ReceiverReport{SSRC: 1, Reports: reports[31:62]},
+		&ReceiverReport{SSRC: 1, Reports: reports[62:]},
+	}, packets)
+
+	compound := append(CompoundPacket(packets), NewCNAMESourceDescription(1, "cname"))
+	_, err = compound.Marshal()
+	assert.NoError(t, err)
+
+	// The returned reports don't alias the given ones
+	first, ok := packets[0].(*SenderReport)
+	assert.True(t, ok)
+	first.Reports = append(first.Reports, ReceptionReport{SSRC: 1})
+	assert.Equal(t, uint32(41), reports[31].SSRC)
+
+	packets, err = SplitReports(&ReceiverReport{SSRC: 1, Reports: reports[:31], ProfileExtensions: []byte{1, 2, 3, 4}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{
+		&ReceiverReport{SSRC: 1, Reports: reports[:31], ProfileExtensions: []byte{1, 2, 3, 4}},
+	}, packets)
+
+	packets, err = SplitReports(&ReceiverReport{SSRC: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{&ReceiverReport{SSRC: 1}}, packets)
+
+	_, err = SplitReports(&Goodbye{})
+	assert.ErrorIs(t, err, errBadFirstPacket)
+}
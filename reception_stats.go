@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// A source is declared valid after this many sequential packets, see RFC 3550 A.1.
+	receptionMinSequential = 2
+	// Largest forward jump in sequence numbers that is not treated as a restart.
+	receptionMaxDropout = 3000
+	// Largest backward jump in sequence numbers that is treated as reordering.
+	receptionMaxMisorder = 100
+	receptionSeqMod      = 1 << 16
+
+	// Number of recent sequence numbers remembered to discard duplicates.
+	receptionHistorySize = 1024
+
+	maxTotalLost = 0x7FFFFF
+	minTotalLost = -0x800000
+
+	// Delay since the last sender report that no longer fits in units of
+	// 1/65536 seconds (about 18 hours).
+	maxReportDelay = 65536 * time.Second
+)
+
+// ReceptionStats tracks the reception of RTP packets from a single
+// synchronization source, and produces the ReceptionReport blocks to be
+// sent in SenderReport and ReceiverReport packets.
+//
+// It implements the algorithms from RFC 3550 Appendix A: source validation
+// and sequence number maintenance (A.1), loss computation (A.3) and
+// interarrival jitter estimation (A.8). Duplicate packets are detected
+// within a window of recent sequence numbers and are not counted as
+// received.
+//
+// ReceptionStats is not safe for concurrent use.
+type ReceptionStats struct {
+	ssrc uint32
+
+	started   bool
+	probation int
+	maxSeq    uint16
+	cycles    uint32
+	baseSeq   uint32
+	badSeq    uint32
+	history   [receptionHistorySize / 64]uint64
+
+	received      uint32
+	expectedPrior uint32
+	receivedPrior uint32
+
+	clockRate       uint32
+	firstArrival    time.Time
+	lastTransit     uint32
+	haveLastTransit bool
+	jitter          float64
+
+	lastSenderReport        uint32
+	lastSenderReportArrival time.Time
+}
+
+// NewReceptionStats returns a ReceptionStats for the source with the given SSRC.
+func NewReceptionStats(ssrc uint32) *ReceptionStats {
+	return &ReceptionStats{ssrc: ssrc}
+}
+
+// ReceivePacket records the arrival of an RTP packet with the given sequence
+// number and RTP timestamp. The clock rate of the payload is used to express
+// the arrival time in RTP timestamp units for the jitter computation.
+func (s *ReceptionStats) ReceivePacket(sequenceNumber uint16, timestamp uint32, arrival time.Time, clockRate uint32) {
+	if !s.updateSequence(sequenceNumber) {
+		return
+	}
+
+	s.updateJitter(timestamp, arrival, clockRate)
+}
+
+// ReceiveSenderReport records the arrival of a SenderReport from the source,
+// given its NTPTime, so that subsequent reception reports carry the matching
+// LastSenderReport and Delay values.
+func (s *ReceptionStats) ReceiveSenderReport(ntpTime uint64, arrival time.Time) {
+	s.lastSenderReport = uint32(ntpTime >> 16) //nolint:gosec // G115, middle 32 bits of the NTP timestamp
+	s.lastSenderReportArrival = arrival
+}
+
+// BuildReceptionReport returns the reception report block for this source,
+// as of the given time, and starts a new reporting interval for the
+// FractionLost computation. It returns false if the source has not been
+// validated yet, in which case no report should be sent for it.
+func (s *ReceptionStats) BuildReceptionReport(now time.Time) (ReceptionReport, bool) {
+	if !s.started || s.probation > 0 {
+		return ReceptionReport{}, false
+	}
+
+	extendedMax := s.cycles + uint32(s.maxSeq)
+	expected := extendedMax - s.baseSeq + 1
+
+	lost := int64(expected) - int64(s.received)
+	if lost > maxTotalLost {
+		lost = maxTotalLost
+	} else if lost < minTotalLost {
+		lost = minTotalLost
+	}
+
+	expectedInterval := expected - s.expectedPrior
+	receivedInterval := s.received - s.receivedPrior
+	s.expectedPrior = expected
+	s.receivedPrior = s.received
+
+	var fractionLost uint8
+	lostInterval := int64(expectedInterval) - int64(receivedInterval)
+	if expectedInterval != 0 && lostInterval > 0 {
+		fractionLost = uint8(min((lostInterval<<8)/int64(expectedInterval), 255)) //nolint:gosec // G115
+	}
+
+	report := ReceptionReport{
+		SSRC:         s.ssrc,
+		FractionLost: fractionLost,
+		// The cumulative number of packets lost is a signed 24-bit value
+		TotalLost:          uint32(lost) & 0xFFFFFF, //nolint:gosec // G115
+		LastSequenceNumber: extendedMax,
+		Jitter:             uint32(s.jitter),
+	}
+
+	if !s.lastSenderReportArrival.IsZero() {
+		report.LastSenderReport = s.lastSenderReport
+		switch delay := now.Sub(s.lastSenderReportArrival); {
+		case delay >= maxReportDelay:
+			report.Delay = math.MaxUint32
+		case delay > 0:
+			report.Delay = uint32(delay * 65536 / time.Second) //nolint:gosec // G115
+		}
+	}
+
+	return report, true
+}
+
+// initSequence starts counting from the given sequence number, see init_seq
+// in RFC 3550 A.1.
+func (s *ReceptionStats) initSequence(seq uint16) {
+	s.baseSeq = uint32(seq)
+	s.maxSeq = seq
+	s.badSeq = receptionSeqMod + 1
+	s.cycles = 0
+	s.received = 0
+	s.receivedPrior = 0
+	s.expectedPrior = 0
+	s.history = [receptionHistorySize / 64]uint64{}
+	s.markReceived(seq)
+	s.haveLastTransit = false
+}
+
+// updateSequence updates the sequence number state for the given packet, see
+// update_seq in RFC 3550 A.1. It returns false if the packet must not be
+// counted, either because the source is not yet valid or because it is a
+// duplicate.
+func (s *ReceptionStats) updateSequence(seq uint16) bool {
+	if !s.started {
+		s.started = true
+		s.initSequence(seq)
+		s.maxSeq = seq - 1
+		s.probation = receptionMinSequential
+	}
+
+	delta := seq - s.maxSeq
+
+	switch {
+	case s.probation > 0:
+		// Packets must be in sequence for the source to be valid
+		if seq != s.maxSeq+1 {
+			s.probation = receptionMinSequential - 1
+			s.maxSeq = seq
+
+			return false
+		}
+
+		s.probation--
+		s.maxSeq = seq
+		if s.probation > 0 {
+			return false
+		}
+		s.initSequence(seq)
+		s.received++
+
+		return true
+
+	case delta < receptionMaxDropout:
+		// In order, with permissible gap
+		if delta == 0 {
+			return false
+		}
+		if seq < s.maxSeq {
+			s.cycles += receptionSeqMod
+		}
+		s.advance(seq)
+
+	case int(delta) <= receptionSeqMod-receptionMaxMisorder:
+		// The sequence number made a very large jump
+		if uint32(seq) != s.badSeq {
+			s.badSeq = uint32(seq+1) & (receptionSeqMod - 1)
+
+			return false
+		}
+		// Two sequential packets; assume that the other side restarted
+		// without telling us so just re-sync
+		s.initSequence(seq)
+
+	default:
+		// Duplicate or reordered packet
+		if s.isReceived(seq) {
+			return false
+		}
+		s.markReceived(seq)
+	}
+
+	s.received++
+
+	return true
+}
+
+// advance moves the highest sequence number forward, forgetting the
+// sequence numbers that fall out of the duplicate detection window.
+func (s *ReceptionStats) advance(seq uint16) {
+	if gap := seq - s.maxSeq; gap >= receptionHistorySize {
+		s.history = [receptionHistorySize / 64]uint64{}
+	} else {
+		for next := s.maxSeq + 1; next != seq; next++ {
+			s.history[next%receptionHistorySize/64] &^= 1 << (next % 64)
+		}
+	}
+	s.maxSeq = seq
+	s.markReceived(seq)
+}
+
+func (s *ReceptionStats) markReceived(seq uint16) {
+	s.history[seq%receptionHistorySize/64] |= 1 << (seq % 64)
+}
+
+func (s *ReceptionStats) isReceived(seq uint16) bool {
+	// Packets older than the window can't be told apart from duplicates
+	if s.maxSeq-seq >= receptionHistorySize {
+		return false
+	}
+
+	return s.history[seq%receptionHistorySize/64]&(1<<(seq%64)) != 0
+}
+
+// updateJitter updates the interarrival jitter estimate, see RFC 3550 A.8.
+func (s *ReceptionStats) updateJitter(timestamp uint32, arrival time.Time, clockRate uint32) {
+	if clockRate == 0 {
+		return
+	}
+	if clockRate != s.clockRate || s.firstArrival.IsZero() {
+		s.clockRate = clockRate
+		s.firstArrival = arrival
+		s.haveLastTransit = false
+	}
+
+	// Arrival time expressed in RTP timestamp units, relative to the
+	// first packet. Only differences between transit times matter.
+	elapsed := arrival.Sub(s.firstArrival)
+	arrivalUnits := int64(elapsed/time.Second)*int64(clockRate) +
+		int64(elapsed%time.Second)*int64(clockRate)/int64(time.Second)
+	transit := uint32(arrivalUnits) - timestamp //nolint:gosec // G115, wraps like RTP timestamps
+
+	if s.haveLastTransit {
+		d := float64(int32(transit - s.lastTransit)) //nolint:gosec // G115, signed difference
+		if d < 0 {
+			d = -d
+		}
+		s.jitter += (d - s.jitter) / 16
+	}
+	s.lastTransit = transit
+	s.haveLastTransit = true
+}
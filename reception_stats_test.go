@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceptionStatsProbation(t *testing.T) {
+	now := time.Unix(1000, 0)
+	stats := NewReceptionStats(0x902f9e2e)
+
+	_, ok := stats.BuildReceptionReport(now)
+	assert.False(t, ok, "no packets received")
+
+	stats.ReceivePacket(10, 0, now, 90000)
+	_, ok = stats.BuildReceptionReport(now)
+	assert.False(t, ok, "source still on probation")
+
+	// Out of sequence packets restart probation
+	stats.ReceivePacket(20, 0, now, 90000)
+	_, ok = stats.BuildReceptionReport(now)
+	assert.False(t, ok, "source still on probation")
+
+	stats.ReceivePacket(21, 0, now, 90000)
+	report, ok := stats.BuildReceptionReport(now)
+	assert.True(t, ok)
+	assert.Equal(t, ReceptionReport{
+		SSRC:               0x902f9e2e,
+		LastSequenceNumber: 21,
+	}, report)
+}
+
+func TestReceptionStatsLoss(t *testing.T) {
+	now := time.Unix(1000, 0)
+	stats := NewReceptionStats(1)
+
+	for _, seq := range []uint16{100, 101, 102, 104, 105, 108} {
+		stats.ReceivePacket(seq, 0, now, 90000)
+	}
+
+	// 101..108 expected, 3 lost
+	report, ok := stats.BuildReceptionReport(now)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(108), report.LastSequenceNumber)
+	assert.Equal(t, uint32(3), report.TotalLost)
+	assert.Equal(t, uint8(3*256/8), report.FractionLost)
+
+	// A late packet recovers one loss, and duplicates are ignored
+	for _, seq := range []uint16{103, 103, 108, 109, 110} {
+		stats.ReceivePacket(seq, 0, now, 90000)
+	}
+	report, ok = stats.BuildReceptionReport(now)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(110), report.LastSequenceNumber)
+	assert.Equal(t, uint32(2), report.TotalLost)
+	assert.Equal(t, uint8(0), report.FractionLost, "more packets than expected in interval")
+
+	// Nothing received since the last report
+	report, ok = stats.BuildReceptionReport(now)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(0), report.FractionLost)
+}
+
+func TestReceptionStatsWraparound(t *testing.T) {
+	now := time.Unix(1000, 0)
+	stats := NewReceptionStats(1)
+
+	for _, seq := range []uint16{65533, 65534, 65535, 1, 0, 2} {
+		stats.ReceivePacket(seq, 0, now, 90000)
+	}
+
+	report, ok := stats.BuildReceptionReport(now)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1<<16|2), report.LastSequenceNumber)
+	assert.Equal(t, uint32(0), report.TotalLost)
+	assert.Equal(t, uint8(0), report.FractionLost)
+}
+
+func TestReceptionStatsRestart(t *testing.T) {
+	now := time.Unix(1000, 0)
+	stats := NewReceptionStats(1)
+
+	for _, seq := range []uint16{1, 2, 3} {
+		stats.ReceivePacket(seq, 0, now, 90000)
+	}
+
+	// A single large jump is discarded
+	stats.ReceivePacket(30000, 0, now, 90000)
+	report, ok := stats.BuildReceptionReport(now)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(3), report.LastSequenceNumber)
+
+	// Two sequential packets after the jump resynchronize
+	stats.ReceivePacket(40000, 0, now, 90000)
+	stats.ReceivePacket(40001, 0, now, 90000)
+	report, ok = stats.BuildReceptionReport(now)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(40001), report.LastSequenceNumber)
+	assert.Equal(t, uint32(0), report.TotalLost)
+}
+
+func TestReceptionStatsJitter(t *testing.T) {
+	start := time.Unix(1000, 0)
+	stats := NewReceptionStats(1)
+
+	// 20ms packets at 8kHz, every second packet arrives 10ms late
+	for i := uint16(0); i < 200; i++ {
+		arrival := start.Add(time.Duration(i) * 20 * time.Millisecond)
+		if i%2 == 1 {
+			arrival = arrival.Add(10 * time.Millisecond)
+		}
+		stats.ReceivePacket(i, uint32(i)*160, arrival, 8000)
+	}
+
+	// The transit time alternates by 80 timestamp units, which the
+	// estimator converges to
+	report, ok := stats.BuildReceptionReport(start)
+	assert.True(t, ok)
+	assert.InDelta(t, 80, report.Jitter, 1)
+}
+
+func TestReceptionStatsSenderReport(t *testing.T) {
+	now := time.Unix(1000, 0)
+	stats := NewReceptionStats(1)
+	stats.ReceivePacket(1, 0, now, 90000)
+	stats.ReceivePacket(2, 0, now, 90000)
+
+	report, ok := stats.BuildReceptionReport(now)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0), report.LastSenderReport)
+	assert.Equal(t, uint32(0), report.Delay)
+
+	stats.ReceiveSenderReport(0xda8bd1fcdddda05a, now)
+	report, ok = stats.BuildReceptionReport(now.Add(1500 * time.Millisecond))
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0xd1fcdddd), report.LastSenderReport)
+	assert.Equal(t, uint32(0x18000), report.Delay)
+
+	_, err := report.Marshal()
+	assert.NoError(t, err)
+
+	// The delay saturates
+	report, _ = stats.BuildReceptionReport(now.Add(40 * time.Hour))
+	assert.Equal(t, uint32(math.MaxUint32), report.Delay)
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+const (
+	rembDefaultMaxAge      = 5 * time.Second
+	rembDefaultMinInterval = time.Second
+	// Estimates dropping below this fraction of the last one sent are sent
+	// without waiting for the minimum interval.
+	rembImmediateDecrease = 0.97
+)
+
+// REMBAggregation is the way a REMBAggregator combines estimates.
+type REMBAggregation int
+
+// REMB aggregations.
+const (
+	// REMBMinimum uses the lowest estimate.
+	REMBMinimum REMBAggregation = iota + 1
+	// REMBPercentile uses the given percentile of the estimates.
+	REMBPercentile
+	// REMBWeighted uses the average of the estimates, weighted by the weight
+	// of their senders.
+	REMBWeighted
+)
+
+// REMBAggregatorConfig configures a REMBAggregator.
+type REMBAggregatorConfig struct {
+	// SenderSSRC of the estimates sent upstream.
+	SenderSSRC uint32
+	// SSRCs of the media sources of the publisher, that the estimates sent
+	// upstream apply to. Received estimates that don't apply to any of
+	// them are ignored, unless SSRCs is empty, in which case the estimates
+	// sent upstream apply to all the SSRCs of the received ones.
+	SSRCs []uint32
+	// Aggregation of the estimates. Zero means REMBMinimum.
+	Aggregation REMBAggregation
+	// Percentile used by REMBPercentile, between 0 and 100.
+	Percentile float64
+	// MaxAge of the estimates, after which they are ignored. Zero means five
+	// seconds.
+	MaxAge time.Duration
+	// MinInterval between estimates sent upstream, unless the estimate
+	// decreases by more than 3%. Zero means one second.
+	MinInterval time.Duration
+}
+
+type rembEstimate struct {
+	bitrate  float32
+	ssrcs    []uint32
+	received time.Time
+}
+
+// REMBAggregator combines the ReceiverEstimatedMaximumBitrate packets
+// received from the subscribers of a publisher, as in an SFU, into the
+// estimates to send to the publisher.
+//
+// REMBAggregator is not safe for concurrent use.
+type REMBAggregator struct {
+	config    REMBAggregatorConfig
+	estimates map[uint32]rembEstimate
+	weights   map[uint32]float64
+	lastSent  time.Time
+	lastRate  float32
+}
+
+// NewREMBAggregator returns a REMBAggregator with the given configuration.
+func NewREMBAggregator(config REMBAggregatorConfig) *REMBAggregator {
+	if config.Aggregation == 0 {
+		config.Aggregation = REMBMinimum
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = rembDefaultMaxAge
+	}
+	if config.MinInterval <= 0 {
+		config.MinInterval = rembDefaultMinInterval
+	}
+
+	return &REMBAggregator{
+		config:    config,
+		estimates: map[uint32]rembEstimate{},
+		weights:   map[uint32]float64{},
+	}
+}
+
+// SetWeight sets the weight of the estimates of a sender for REMBWeighted.
+// The default weight is 1.
+func (a *REMBAggregator) SetWeight(senderSSRC uint32, weight float64) {
+	a.weights[senderSSRC] = weight
+}
+
+// Receive records the estimate of a packet received at the given time,
+// replacing the previous estimate of its sender.
+func (a *REMBAggregator) Receive(packet *ReceiverEstimatedMaximumBitrate, now time.Time) {
+	if len(a.config.SSRCs) != 0 && !slices.ContainsFunc(packet.SSRCs, func(ssrc uint32) bool {
+		return slices.Contains(a.config.SSRCs, ssrc)
+	}) {
+		return
+	}
+
+	a.estimates[packet.SenderSSRC] = rembEstimate{
+		bitrate:  packet.Bitrate,
+		ssrcs:    slices.Clone(packet.SSRCs),
+		received: now,
+	}
+}
+
+// Bitrate returns the combination of the estimates that are not expired at
+// the given time, or false if there are none.
+func (a *REMBAggregator) Bitrate(now time.Time) (float32, bool) {
+	var bitrates []float32
+	var weighted, weights float64
+	for sender, estimate := range a.estimates {
+		if now.Sub(estimate.received) > a.config.MaxAge {
+			delete(a.estimates, sender)
+
+			continue
+		}
+
+		bitrates = append(bitrates, estimate.bitrate)
+		weight, ok := a.weights[sender]
+		if !ok {
+			weight = 1
+		}
+		weighted += weight * float64(estimate.bitrate)
+		weights += weight
+	}
+	if len(bitrates) == 0 {
+		return 0, false
+	}
+
+	switch a.config.Aggregation {
+	case REMBPercentile:
+		slices.Sort(bitrates)
+		// Nearest-rank percentile
+		rank := int(math.Ceil(a.config.Percentile / 100 * float64(len(bitrates))))
+
+		return bitrates[min(max(rank-1, 0), len(bitrates)-1)], true
+	case REMBWeighted:
+		if weights <= 0 {
+			return 0, false
+		}
+
+		return float32(weighted / weights), true
+	default:
+		return slices.Min(bitrates), true
+	}
+}
+
+// Update returns the ReceiverEstimatedMaximumBitrate packet to send upstream
+// at the given time, or nil if there is no estimate or it is not due yet,
+// and records that it is sent.
+func (a *REMBAggregator) Update(now time.Time) *ReceiverEstimatedMaximumBitrate {
+	bitrate, ok := a.Bitrate(now)
+	if !ok {
+		return nil
+	}
+
+	if !a.lastSent.IsZero() && now.Sub(a.lastSent) < a.config.MinInterval &&
+		bitrate >= a.lastRate*rembImmediateDecrease {
+		return nil
+	}
+
+	a.lastSent = now
+	a.lastRate = bitrate
+
+	return &ReceiverEstimatedMaximumBitrate{
+		SenderSSRC: a.config.SenderSSRC,
+		Bitrate:    bitrate,
+		SSRCs:      a.ssrcs(),
+	}
+}
+
+// ssrcs returns the configured SSRCs, or else the sorted SSRCs of the
+// estimates left by Bitrate.
+func (a *REMBAggregator) ssrcs() []uint32 {
+	if len(a.config.SSRCs) != 0 {
+		return slices.Clone(a.config.SSRCs)
+	}
+
+	var ssrcs []uint32
+	for _, estimate := range a.estimates {
+		ssrcs = append(ssrcs, estimate.ssrcs...)
+	}
+	slices.Sort(ssrcs)
+
+	return slices.Compact(ssrcs)
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestREMBAggregator(t *testing.T) {
+	now := time.Unix(1000, 0)
+	receive := func(aggregator *REMBAggregator, now time.Time) {
+		aggregator.Receive(&ReceiverEstimatedMaximumBitrate{SenderSSRC: 10, Bitrate: 1000, SSRCs: []uint32{2}}, now)
+		aggregator.Receive(&ReceiverEstimatedMaximumBitrate{SenderSSRC: 11, Bitrate: 2000, SSRCs: []uint32{3, 2}}, now)
+		aggregator.Receive(&ReceiverEstimatedMaximumBitrate{SenderSSRC: 12, Bitrate: 4000, SSRCs: []uint32{3}}, now)
+		aggregator.Receive(&ReceiverEstimatedMaximumBitrate{SenderSSRC: 13, Bitrate: 3000, SSRCs: []uint32{2}}, now)
+		// Another publisher
+		aggregator.Receive(&ReceiverEstimatedMaximumBitrate{SenderSSRC: 14, Bitrate: 10, SSRCs: []uint32{4}}, now)
+	}
+
+	for _, test := range []struct {
+		name    string
+		config  REMBAggregatorConfig
+		bitrate float32
+	}{
+		{"minimum", REMBAggregatorConfig{}, 1000},
+		{"percentile", REMBAggregatorConfig{Aggregation: REMBPercentile, Percentile: 50}, 2000},
+		{"maximum percentile", REMBAggregatorConfig{Aggregation: REMBPercentile, Percentile: 100}, 4000},
+		{"weighted", REMBAggregatorConfig{Aggregation: REMBWeighted}, 2500},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.SSRCs = []uint32{2, 3}
+			aggregator := NewREMBAggregator(test.config)
+			_, ok := aggregator.Bitrate(now)
+			assert.False(t, ok)
+
+			receive(aggregator, now)
+			bitrate, ok := aggregator.Bitrate(now)
+			assert.True(t, ok)
+			assert.Equal(t, test.bitrate, bitrate)
+		})
+	}
+
+	t.Run("weights", func(t *testing.T) {
+		aggregator := NewREMBAggregator(REMBAggregatorConfig{Aggregation: REMBWeighted})
+		aggregator.SetWeight(10, 3)
+		aggregator.SetWeight(12, 0)
+		receive(aggregator, now)
+		bitrate, ok := aggregator.Bitrate(now)
+		assert.True(t, ok)
+		assert.Equal(t, float32(8010)/6, bitrate)
+	})
+
+	t.Run("updates", func(t *testing.T) {
+		aggregator := NewREMBAggregator(REMBAggregatorConfig{SenderSSRC: 1, SSRCs: []uint32{2, 3}, MaxAge: 2 * time.Second})
+		assert.Nil(t, aggregator.Update(now))
+
+		receive(aggregator, now)
+		assert.Equal(t, &ReceiverEstimatedMaximumBitrate{
+			SenderSSRC: 1,
+			Bitrate:    1000,
+			SSRCs:      []uint32{2, 3},
+		}, aggregator.Update(now))
+
+		// Rate limited, unless decreasing
+		aggregator.Receive(&ReceiverEstimatedMaximumBitrate{SenderSSRC: 10, Bitrate: 980, SSRCs: []uint32{2}}, now)
+		assert.Nil(t, aggregator.Update(now.Add(500*time.Millisecond)))
+		aggregator.Receive(&ReceiverEstimatedMaximumBitrate{SenderSSRC: 10, Bitrate: 900, SSRCs: []uint32{2}}, now)
+		assert.Equal(t, float32(900), aggregator.Update(now.Add(500*time.Millisecond)).Bitrate)
+		assert.Equal(t, float32(900), aggregator.Update(now.Add(1500*time.Millisecond)).Bitrate)
+
+		// Expired estimates
+		later := now.Add(time.Second)
+		aggregator.Receive(&ReceiverEstimatedMaximumBitrate{SenderSSRC: 12, Bitrate: 4000, SSRCs: []uint32{3}}, later)
+		assert.Equal(t, float32(4000), aggregator.Update(now.Add(2500*time.Millisecond)).Bitrate)
+		assert.Nil(t, aggregator.Update(now.Add(4*time.Second)))
+	})
+
+	t.Run("no configured SSRCs", func(t *testing.T) {
+		aggregator := NewREMBAggregator(REMBAggregatorConfig{SenderSSRC: 1, MaxAge: 2 * time.Second})
+		receive(aggregator, now)
+		expired := &ReceiverEstimatedMaximumBitrate{SenderSSRC: 15, Bitrate: 20, SSRCs: []uint32{5}}
+		aggregator.Receive(expired, now.Add(-time.Second))
+
+		// The SSRCs of the unexpired estimates
+		assert.Equal(t, &ReceiverEstimatedMaximumBitrate{
+			SenderSSRC: 1,
+			Bitrate:    10,
+			SSRCs:      []uint32{2, 3, 4},
+		}, aggregator.Update(now.Add(1500*time.Millisecond)))
+	})
+}
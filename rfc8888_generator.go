@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// Arrival time offset reported for arrivals too long before the report
+	// timestamp, see RFC 8888 section 3.1.
+	ccfbOffsetOverRange = 0x1FFE
+	// Arrival time offset reported when the arrival time is unavailable.
+	ccfbOffsetUnavailable = 0x1FFF
+	ccfbOffsetUnits       = 1024
+
+	ccfbHeaderLength = reportBlockOffset + reportTimestampLength
+	// Largest packet allowed by the header length field.
+	ccfbMaxPacketLength = (1 << 16) * 4
+)
+
+type ccfbArrival struct {
+	// Set for packets reported lost by a converted feedback packet, so that
+	// the packet range covers them. A later arrival replaces them.
+	lost bool
+	// Zero if the arrival time is unavailable
+	arrival time.Time
+	ecn     ECN
+}
+
+type ccfbStream struct {
+	// Unwrapped sequence numbers of the first packet not reported yet, and
+	// of the highest packet received
+	next    int64
+	highest int64
+	// Arrivals not reported yet, by unwrapped sequence number
+	arrivals map[int64]ccfbArrival
+	reported bool
+}
+
+// CCFeedbackGenerator records the arrival of RTP packets from any number of
+// media sources and generates the RFC 8888 CCFeedbackReport packets that
+// report them.
+//
+// Each report covers, for every source, the packets from the first one not
+// reported yet to the highest received, the others being reported as not
+// received. Packets arriving after their sequence number was reported are
+// ignored.
+//
+// CCFeedbackGenerator is not safe for concurrent use.
+type CCFeedbackGenerator struct {
+	senderSSRC uint32
+	maxSize    int
+	streams    map[uint32]*ccfbStream
+}
+
+// NewCCFeedbackGenerator returns a CCFeedbackGenerator for reports sent by
+// senderSSRC, with packets of at most maxSize octets. A maxSize of zero only
+// limits packets to the largest size that can be encoded.
+func NewCCFeedbackGenerator(senderSSRC uint32, maxSize int) *CCFeedbackGenerator {
+	if maxSize <= 0 || maxSize > ccfbMaxPacketLength {
+		maxSize = ccfbMaxPacketLength
+	}
+
+	return &CCFeedbackGenerator{
+		senderSSRC: senderSSRC,
+		maxSize:    maxSize,
+		streams:    map[uint32]*ccfbStream{},
+	}
+}
+
+// AddArrival records the arrival of the RTP packet with the given sequence
+// number from mediaSSRC, with the ECN bits of its IP header. A zero arrival
+// time is reported as unavailable.
+func (g *CCFeedbackGenerator) AddArrival(mediaSSRC uint32, sequenceNumber uint16, arrival time.Time, ecn ECN) {
+	g.add(mediaSSRC, sequenceNumber, ccfbArrival{arrival: arrival, ecn: ecn})
+}
+
+func (g *CCFeedbackGenerator) add(mediaSSRC uint32, sequenceNumber uint16, arrival ccfbArrival) {
+	stream, ok := g.streams[mediaSSRC]
+	if !ok {
+		stream = &ccfbStream{
+			next:     int64(sequenceNumber),
+			highest:  int64(sequenceNumber),
+			arrivals: map[int64]ccfbArrival{},
+		}
+		g.streams[mediaSSRC] = stream
+	}
+
+	unwrapped := stream.highest + int64(int16(sequenceNumber-uint16(stream.highest))) //nolint:gosec // G115
+	if unwrapped < stream.next {
+		if stream.reported {
+			return
+		}
+		stream.next = unwrapped
+	}
+	if previous, ok := stream.arrivals[unwrapped]; ok && !previous.lost {
+		return
+	}
+	stream.arrivals[unwrapped] = arrival
+	stream.highest = max(stream.highest, unwrapped)
+
+	// Forget the oldest packets that can't be reported in a single block
+	if stream.highest-stream.next >= maxMetricBlocks {
+		stream.next = stream.highest - maxMetricBlocks + 1
+		for seq := range stream.arrivals {
+			if seq < stream.next {
+				delete(stream.arrivals, seq)
+			}
+		}
+	}
+}
+
+// BuildReports returns the reports for the packets received since the
+// previous reports, with a report timestamp for the given time, or nil if
+// there are none. Reports are split as needed to fit in the size limit.
+func (g *CCFeedbackGenerator) BuildReports(now time.Time) ([]*CCFeedbackReport, error) {
+	if g.maxSize < ccfbHeaderLength+reportsOffset+2*metricBlockLength {
+		return nil, errCCFBSizeTooSmall
+	}
+
+	reportTimestamp := NewNTPTime(now).Compact()
+	newReport := func() *CCFeedbackReport {
+		return &CCFeedbackReport{SenderSSRC: g.senderSSRC, ReportTimestamp: uint32(reportTimestamp)}
+	}
+
+	var reports []*CCFeedbackReport
+	var current *CCFeedbackReport
+	size := 0
+	for _, ssrc := range g.pendingSSRCs() {
+		stream := g.streams[ssrc]
+		metrics := stream.metricBlocks(now)
+		begin := uint16(stream.next) //nolint:gosec // G115
+		stream.next = stream.highest + 1
+		stream.reported = true
+		clear(stream.arrivals)
+
+		for len(metrics) > 0 {
+			// Blocks are padded to an even number of metric blocks
+			count := min(len(metrics), (g.maxSize-size-reportsOffset)/(2*metricBlockLength)*2)
+			if current == nil || count <= 0 {
+				current = newReport()
+				reports = append(reports, current)
+				size = ccfbHeaderLength
+				continue
+			}
+
+			block := CCFeedbackReportBlock{MediaSSRC: ssrc, BeginSequence: begin, MetricBlocks: metrics[:count]}
+			current.ReportBlocks = append(current.ReportBlocks, block)
+			size += block.len()
+			begin += uint16(count) //nolint:gosec // G115
+			metrics = metrics[count:]
+		}
+	}
+
+	return reports, nil
+}
+
+func (g *CCFeedbackGenerator) pendingSSRCs() []uint32 {
+	var ssrcs []uint32
+	for ssrc, stream := range g.streams {
+		if stream.highest >= stream.next {
+			ssrcs = append(ssrcs, ssrc)
+		}
+	}
+	slices.Sort(ssrcs)
+
+	return ssrcs
+}
+
+// metricBlocks returns the metric blocks of the packets not reported yet.
+func (s *ccfbStream) metricBlocks(now time.Time) []CCFeedbackMetricBlock {
+	metrics := make([]CCFeedbackMetricBlock, s.highest-s.next+1)
+	for seq, arrival := range s.arrivals {
+		if arrival.lost {
+			continue
+		}
+
+		offset := uint16(ccfbOffsetUnavailable)
+		if !arrival.arrival.IsZero() {
+			offset = ccfbArrivalTimeOffset(now.Sub(arrival.arrival))
+		}
+		metrics[seq-s.next] = CCFeedbackMetricBlock{Received: true, ECN: arrival.ecn, ArrivalTimeOffset: offset}
+	}
+
+	return metrics
+}
+
+// ccfbArrivalTimeOffset returns the arrival time offset, in 1/1024 seconds,
+// of a packet that arrived the given time before the report timestamp.
+func ccfbArrivalTimeOffset(offset time.Duration) uint16 {
+	if offset < 0 {
+		return ccfbOffsetUnavailable
+	}
+
+	units := offset * ccfbOffsetUnits / time.Second
+	if units >= ccfbOffsetOverRange {
+		return ccfbOffsetOverRange
+	}
+
+	return uint16(units) //nolint:gosec // G115
+}
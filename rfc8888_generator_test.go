@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCCFeedbackGenerator(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	t.Run("arrivals", func(t *testing.T) {
+		generator := NewCCFeedbackGenerator(1, 0)
+		generator.AddArrival(2, 65534, now, ECNECT0)
+		generator.AddArrival(2, 1, now.Add(10*time.Millisecond), ECNCE)
+		generator.AddArrival(2, 65535, now.Add(5*time.Millisecond), ECNNonECT)
+		generator.AddArrival(2, 1, now.Add(20*time.Millisecond), ECNNonECT)
+		generator.AddArrival(3, 100, now.Add(-time.Minute), ECNECT1)
+		generator.AddArrival(3, 101, now.Add(time.Second), ECNECT1)
+
+		reports, err := generator.BuildReports(now.Add(500 * time.Millisecond))
+		assert.NoError(t, err)
+		assert.Equal(t, []*CCFeedbackReport{{
+			SenderSSRC: 1,
+			ReportBlocks: []CCFeedbackReportBlock{
+				{
+					MediaSSRC:     2,
+					BeginSequence: 65534,
+					MetricBlocks: []CCFeedbackMetricBlock{
+						{Received: true, ECN: ECNECT0, ArrivalTimeOffset: 512},
+						{Received: true, ECN: ECNNonECT, ArrivalTimeOffset: 506},
+						{},
+						{Received: true, ECN: ECNCE, ArrivalTimeOffset: 501},
+					},
+				},
+				{
+					MediaSSRC:     3,
+					BeginSequence: 100,
+					MetricBlocks: []CCFeedbackMetricBlock{
+						{Received: true, ECN: ECNECT1, ArrivalTimeOffset: ccfbOffsetOverRange},
+						{Received: true, ECN: ECNECT1, ArrivalTimeOffset: ccfbOffsetUnavailable},
+					},
+				},
+			},
+			ReportTimestamp: uint32(NewCompactNTPTime(now.Add(500 * time.Millisecond))),
+		}}, reports)
+
+		raw, err := reports[0].Marshal()
+		assert.NoError(t, err)
+		var decoded CCFeedbackReport
+		assert.NoError(t, decoded.Unmarshal(raw))
+		assert.Equal(t, *reports[0], decoded)
+	})
+
+	t.Run("continues after previous report", func(t *testing.T) {
+		generator := NewCCFeedbackGenerator(1, 0)
+		generator.AddArrival(2, 10, now, ECNNonECT)
+		generator.AddArrival(2, 12, now, ECNNonECT)
+		reports, err := generator.BuildReports(now)
+		assert.NoError(t, err)
+		assert.Len(t, reports, 1)
+
+		reports, err = generator.BuildReports(now)
+		assert.NoError(t, err)
+		assert.Nil(t, reports)
+
+		// Late arrivals of reported packets are ignored
+		generator.AddArrival(2, 11, now, ECNNonECT)
+		generator.AddArrival(2, 14, now, ECNNonECT)
+		reports, err = generator.BuildReports(now.Add(time.Second))
+		assert.NoError(t, err)
+		assert.Len(t, reports, 1)
+		assert.Equal(t, []CCFeedbackReportBlock{{
+			MediaSSRC:     2,
+			BeginSequence: 13,
+			MetricBlocks: []CCFeedbackMetricBlock{
+				{},
+				{Received: true, ArrivalTimeOffset: 1024},
+			},
+		}}, reports[0].ReportBlocks)
+	})
+
+	t.Run("too many packets", func(t *testing.T) {
+		generator := NewCCFeedbackGenerator(1, 0)
+		generator.AddArrival(2, 0, now, ECNNonECT)
+		generator.AddArrival(2, maxMetricBlocks, now, ECNNonECT)
+		reports, err := generator.BuildReports(now)
+		assert.NoError(t, err)
+		assert.Len(t, reports, 1)
+		assert.Len(t, reports[0].ReportBlocks, 1)
+		assert.Equal(t, uint16(1), reports[0].ReportBlocks[0].BeginSequence)
+		assert.Len(t, reports[0].ReportBlocks[0].MetricBlocks, maxMetricBlocks)
+	})
+
+	t.Run("split", func(t *testing.T) {
+		generator := NewCCFeedbackGenerator(1, 40)
+		for seq := uint16(0); seq < 13; seq++ {
+			generator.AddArrival(2, seq, now, ECNNonECT)
+		}
+		generator.AddArrival(3, 7, now, ECNNonECT)
+
+		reports, err := generator.BuildReports(now)
+		assert.NoError(t, err)
+
+		var begins []uint16
+		var lengths []int
+		for _, report := range reports {
+			assert.LessOrEqual(t, report.MarshalSize(), 40)
+			for _, block := range report.ReportBlocks {
+				begins = append(begins, block.BeginSequence)
+				lengths = append(lengths, len(block.MetricBlocks))
+			}
+		}
+		assert.Len(t, reports, 2)
+		assert.Equal(t, []uint16{0, 10, 7}, begins)
+		assert.Equal(t, []int{10, 3, 1}, lengths)
+		assert.Equal(t, uint32(3), reports[1].ReportBlocks[1].MediaSSRC)
+	})
+
+	t.Run("size too small", func(t *testing.T) {
+		generator := NewCCFeedbackGenerator(1, 20)
+		generator.AddArrival(2, 0, now, ECNNonECT)
+		_, err := generator.BuildReports(now)
+		assert.ErrorIs(t, err, errCCFBSizeTooSmall)
+	})
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// RoundTripTime computes the round-trip time to the receiver that sent this
+// reception report, given the local time at which the report arrived, as
+// described in RFC 3550 section 6.4.1. It returns false if the receiver has
+// not received a SenderReport yet, or if the result is negative, which
+// happens when the report does not match the local clock.
+func (r ReceptionReport) RoundTripTime(arrival time.Time) (time.Duration, bool) {
+	return roundTripTime(arrival, r.LastSenderReport, r.Delay)
+}
+
+// RoundTripTime computes the round-trip time to the receiver described by
+// this DLRR sub-block, given the local time at which the ExtendedReport
+// arrived, as described in RFC 3611 section 4.5. It returns false if no
+// ReceiverReferenceTimeReportBlock has been received from that receiver
+// yet, or if the result is negative.
+func (r DLRRReport) RoundTripTime(arrival time.Time) (time.Duration, bool) {
+	return roundTripTime(arrival, r.LastRR, r.DLRR)
+}
+
+// roundTripTime computes A - LSR - DLSR using the middle 32 bits of the
+// NTP timestamps, whose arithmetic wraps around every 18 hours.
+func roundTripTime(arrival time.Time, lastReport, delay uint32) (time.Duration, bool) {
+	if lastReport == 0 {
+		return 0, false
+	}
+
+	now := uint32(ntpTimestamp(arrival) >> 16) //nolint:gosec // G115, middle 32 bits of the NTP timestamp
+
+	rtt := now - lastReport - delay
+	if int32(rtt) < 0 { //nolint:gosec // G115, checking the sign of the modular difference
+		return 0, false
+	}
+
+	return time.Duration(rtt) * time.Second / 65536, true
+}
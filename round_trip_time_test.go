@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTripTime(t *testing.T) {
+	sent := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// The compact NTP timestamp wraps around one second later
+	wrapping := time.Unix(0, 0).Add(-ntpEpochOffset * time.Second).Add(65535 * time.Second)
+
+	for _, test := range []struct {
+		Name      string
+		Sent      time.Time
+		Delay     time.Duration
+		Arrival   time.Time
+		Want      time.Duration
+		WantValid bool
+	}{
+		{
+			Name:      "valid",
+			Sent:      sent,
+			Delay:     500 * time.Millisecond,
+			Arrival:   sent.Add(600 * time.Millisecond),
+			Want:      100 * time.Millisecond,
+			WantValid: true,
+		},
+		{
+			Name:      "no delay",
+			Sent:      sent,
+			Arrival:   sent.Add(40 * time.Millisecond),
+			Want:      40 * time.Millisecond,
+			WantValid: true,
+		},
+		{
+			Name:      "wraparound",
+			Sent:      wrapping.Add(500 * time.Millisecond),
+			Delay:     time.Second,
+			Arrival:   wrapping.Add(1750 * time.Millisecond),
+			Want:      250 * time.Millisecond,
+			WantValid: true,
+		},
+		{
+			Name:    "negative",
+			Sent:    sent,
+			Delay:   time.Second,
+			Arrival: sent.Add(500 * time.Millisecond),
+		},
+	} {
+		lastReport := uint32(ntpTimestamp(test.Sent) >> 16) //nolint:gosec // G115
+		delay := uint32(test.Delay * 65536 / time.Second)
+
+		rr := ReceptionReport{LastSenderReport: lastReport, Delay: delay}
+		rtt, valid := rr.RoundTripTime(test.Arrival)
+		assert.Equalf(t, test.WantValid, valid, "ReceptionReport %q", test.Name)
+		assert.InDeltaf(t, test.Want, rtt, float64(100*time.Microsecond), "ReceptionReport %q", test.Name)
+
+		dlrr := DLRRReport{LastRR: lastReport, DLRR: delay}
+		rtt, valid = dlrr.RoundTripTime(test.Arrival)
+		assert.Equalf(t, test.WantValid, valid, "DLRRReport %q", test.Name)
+		assert.InDeltaf(t, test.Want, rtt, float64(100*time.Microsecond), "DLRRReport %q", test.Name)
+	}
+
+	// No SR has been received yet
+	_, valid := ReceptionReport{Delay: 100}.RoundTripTime(sent)
+	assert.False(t, valid)
+	_, valid = DLRRReport{}.RoundTripTime(sent)
+	assert.False(t, valid)
+}
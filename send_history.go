@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// Packets are kept in the send history for this long by default.
+const sendHistoryDefaultMaxAge = 10 * time.Second
+
+// SentPacket records the sending of an RTP packet.
+type SentPacket struct {
+	SSRC           uint32
+	SequenceNumber uint16
+	// TransportSequenceNumber from the transport-wide congestion control
+	// header extension, if HasTransportSequenceNumber is true.
+	TransportSequenceNumber    uint16
+	HasTransportSequenceNumber bool
+	SendTime                   time.Time
+	// Size of the packet in bytes.
+	Size int
+}
+
+// PacketFeedback joins the feedback about a packet with its sending.
+type PacketFeedback struct {
+	SentPacket
+	// Received is false if the packet was reported lost.
+	Received bool
+	// ArrivalTime of a received packet on the receiver's clock, as described
+	// for PacketResult.
+	ArrivalTime        time.Duration
+	ArrivalTimeUnknown bool
+	ECN                ECN
+}
+
+// SendHistoryFeedback is the result of matching a feedback packet with the
+// send history.
+type SendHistoryFeedback struct {
+	// Packets reported by the feedback that were found in the history, in
+	// the order of the feedback.
+	Packets []PacketFeedback
+	// AckedBytes is the size of the packets reported received for the first
+	// time.
+	AckedBytes int
+	// LostBytes is the size of the packets reported lost for the first
+	// time, and not received before.
+	LostBytes int
+}
+
+type ssrcSequenceNumber struct {
+	ssrc           uint32
+	sequenceNumber uint16
+}
+
+type sentEntry struct {
+	SentPacket
+	acked bool
+	lost  bool
+}
+
+// SendHistory records the packets sent, to match them with the
+// TransportLayerCC and CCFeedbackReport feedback about them. Packets are
+// identified by their transport-wide sequence number for TransportLayerCC,
+// and by their SSRC and RTP sequence number for CCFeedbackReport.
+//
+// SendHistory is not safe for concurrent use.
+type SendHistory struct {
+	maxAge         time.Duration
+	entries        []*sentEntry
+	transportWide  map[uint16]*sentEntry
+	sequenceNumber map[ssrcSequenceNumber]*sentEntry
+}
+
+// NewSendHistory returns a SendHistory that forgets packets sent more than
+// maxAge before the latest one. A maxAge of zero keeps packets for 10
+// seconds.
+func NewSendHistory(maxAge time.Duration) *SendHistory {
+	if maxAge <= 0 {
+		maxAge = sendHistoryDefaultMaxAge
+	}
+
+	return &SendHistory{
+		maxAge:         maxAge,
+		transportWide:  map[uint16]*sentEntry{},
+		sequenceNumber: map[ssrcSequenceNumber]*sentEntry{},
+	}
+}
+
+// Add records a packet sent, replacing any packet sent before with the same
+// sequence numbers.
+func (h *SendHistory) Add(packet SentPacket) {
+	h.expire(packet.SendTime)
+
+	entry := &sentEntry{SentPacket: packet}
+	h.entries = append(h.entries, entry)
+	h.sequenceNumber[ssrcSequenceNumber{packet.SSRC, packet.SequenceNumber}] = entry
+	if packet.HasTransportSequenceNumber {
+		h.transportWide[packet.TransportSequenceNumber] = entry
+	}
+}
+
+// PacketSize returns the size of the packet sent with the given SSRC and RTP
+// sequence number, if it is still in the history.
+func (h *SendHistory) PacketSize(ssrc uint32, sequenceNumber uint16) (int, bool) {
+	entry, ok := h.sequenceNumber[ssrcSequenceNumber{ssrc, sequenceNumber}]
+	if !ok {
+		return 0, false
+	}
+
+	return entry.Size, true
+}
+
+// TransportLayerCC matches the packets reported by a TransportLayerCC
+// packet with the history, using their transport-wide sequence numbers.
+func (h *SendHistory) TransportLayerCC(packet *TransportLayerCC) (SendHistoryFeedback, error) {
+	results, err := PacketResultsFromTransportLayerCC(packet)
+	if err != nil {
+		return SendHistoryFeedback{}, err
+	}
+
+	return h.match(results, func(result PacketResult) *sentEntry {
+		return h.transportWide[result.SequenceNumber]
+	}), nil
+}
+
+// CCFeedbackReport matches the packets reported by a CCFeedbackReport with
+// the history, using their SSRCs and RTP sequence numbers.
+func (h *SendHistory) CCFeedbackReport(packet *CCFeedbackReport) SendHistoryFeedback {
+	return h.match(PacketResultsFromCCFeedbackReport(packet), func(result PacketResult) *sentEntry {
+		return h.sequenceNumber[ssrcSequenceNumber{result.MediaSSRC, result.SequenceNumber}]
+	})
+}
+
+func (h *SendHistory) match(results []PacketResult, lookup func(PacketResult) *sentEntry) SendHistoryFeedback {
+	var feedback SendHistoryFeedback
+	for _, result := range results {
+		entry := lookup(result)
+		if entry == nil {
+			continue
+		}
+
+		switch {
+		case result.Received && !entry.acked:
+			entry.acked = true
+			feedback.AckedBytes += entry.Size
+		case !result.Received && !entry.acked && !entry.lost:
+			entry.lost = true
+			feedback.LostBytes += entry.Size
+		}
+
+		feedback.Packets = append(feedback.Packets, PacketFeedback{
+			SentPacket:         entry.SentPacket,
+			Received:           result.Received,
+			ArrivalTime:        result.ArrivalTime,
+			ArrivalTimeUnknown: result.ArrivalTimeUnknown,
+			ECN:                result.ECN,
+		})
+	}
+
+	return feedback
+}
+
+// expire forgets the packets sent more than maxAge before now.
+func (h *SendHistory) expire(now time.Time) {
+	deadline := now.Add(-h.maxAge)
+	expired := 0
+	for _, entry := range h.entries {
+		if !entry.SendTime.Before(deadline) {
+			break
+		}
+		expired++
+
+		key := ssrcSequenceNumber{entry.SSRC, entry.SequenceNumber}
+		if h.sequenceNumber[key] == entry {
+			delete(h.sequenceNumber, key)
+		}
+		if entry.HasTransportSequenceNumber && h.transportWide[entry.TransportSequenceNumber] == entry {
+			delete(h.transportWide, entry.TransportSequenceNumber)
+		}
+	}
+	h.entries = h.entries[expired:]
+}
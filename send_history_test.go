@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendHistory(t *testing.T) {
+	now := time.Unix(1000, 0)
+	sent := func(history *SendHistory) []SentPacket {
+		var packets []SentPacket
+		for i := range 4 {
+			packet := SentPacket{
+				SSRC:                       2,
+				SequenceNumber:             uint16(100 + i),   //nolint:gosec // G115
+				TransportSequenceNumber:    uint16(65534 + i), //nolint:gosec // G115
+				HasTransportSequenceNumber: true,
+				SendTime:                   now.Add(time.Duration(i) * 10 * time.Millisecond),
+				Size:                       1000 + i,
+			}
+			history.Add(packet)
+			packets = append(packets, packet)
+		}
+
+		return packets
+	}
+
+	t.Run("TransportLayerCC", func(t *testing.T) {
+		history := NewSendHistory(0)
+		packets := sent(history)
+
+		twcc, err := NewTransportLayerCCBuilder(1, 2, 0).Build([]TransportLayerCCArrival{
+			// Not in the history
+			{SequenceNumber: 65533, Received: true, ArrivalTime: 40 * time.Millisecond},
+			{SequenceNumber: 65534, Received: true, ArrivalTime: 50 * time.Millisecond},
+			{SequenceNumber: 65535},
+			{SequenceNumber: 0, Received: true, ArrivalTime: 75 * time.Millisecond},
+		})
+		assert.NoError(t, err)
+
+		feedback, err := history.TransportLayerCC(twcc[0])
+		assert.NoError(t, err)
+		assert.Equal(t, SendHistoryFeedback{
+			Packets: []PacketFeedback{
+				{SentPacket: packets[0], Received: true, ArrivalTime: 50 * time.Millisecond},
+				{SentPacket: packets[1]},
+				{SentPacket: packets[2], Received: true, ArrivalTime: 75 * time.Millisecond},
+			},
+			AckedBytes: 1000 + 1002,
+			LostBytes:  1001,
+		}, feedback)
+
+		// Bytes are only counted the first time, but a lost packet can be
+		// received later
+		twcc, err = NewTransportLayerCCBuilder(1, 2, 0).Build([]TransportLayerCCArrival{
+			{SequenceNumber: 65535, Received: true, ArrivalTime: 90 * time.Millisecond},
+			{SequenceNumber: 0, Received: true, ArrivalTime: 75 * time.Millisecond},
+			{SequenceNumber: 1},
+		})
+		assert.NoError(t, err)
+		feedback, err = history.TransportLayerCC(twcc[0])
+		assert.NoError(t, err)
+		assert.Len(t, feedback.Packets, 3)
+		assert.Equal(t, 1001, feedback.AckedBytes)
+		assert.Equal(t, 1003, feedback.LostBytes)
+
+		_, err = history.TransportLayerCC(&TransportLayerCC{PacketStatusCount: 1})
+		assert.ErrorIs(t, err, errTCCDeltasMismatch)
+	})
+
+	t.Run("CCFeedbackReport", func(t *testing.T) {
+		history := NewSendHistory(0)
+		packets := sent(history)
+
+		feedback := history.CCFeedbackReport(&CCFeedbackReport{
+			SenderSSRC: 1,
+			ReportBlocks: []CCFeedbackReportBlock{
+				{
+					MediaSSRC:     2,
+					BeginSequence: 102,
+					MetricBlocks: []CCFeedbackMetricBlock{
+						{Received: true, ECN: ECNCE, ArrivalTimeOffset: 1024},
+						{},
+					},
+				},
+				{
+					MediaSSRC:     3,
+					BeginSequence: 100,
+					MetricBlocks:  []CCFeedbackMetricBlock{{Received: true}},
+				},
+			},
+			ReportTimestamp: 10 << 16,
+		})
+		assert.Equal(t, SendHistoryFeedback{
+			Packets: []PacketFeedback{
+				{SentPacket: packets[2], Received: true, ArrivalTime: 9 * time.Second, ECN: ECNCE},
+				{SentPacket: packets[3]},
+			},
+			AckedBytes: 1002,
+			LostBytes:  1003,
+		}, feedback)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		history := NewSendHistory(time.Second)
+		sent(history)
+		history.Add(SentPacket{SSRC: 2, SequenceNumber: 101, SendTime: now.Add(1015 * time.Millisecond), Size: 10})
+
+		feedback := history.CCFeedbackReport(&CCFeedbackReport{
+			ReportBlocks: []CCFeedbackReportBlock{{
+				MediaSSRC:     2,
+				BeginSequence: 100,
+				MetricBlocks:  []CCFeedbackMetricBlock{{}, {}, {}, {}},
+			}},
+		})
+		var sequenceNumbers []uint16
+		for _, packet := range feedback.Packets {
+			sequenceNumbers = append(sequenceNumbers, packet.SequenceNumber)
+		}
+		assert.Equal(t, []uint16{101, 102, 103}, sequenceNumbers)
+		assert.Equal(t, 10+1002+1003, feedback.LostBytes)
+
+		// Expired packets are also forgotten by transport-wide sequence number
+		twcc, err := NewTransportLayerCCBuilder(1, 2, 0).Build([]TransportLayerCCArrival{
+			{SequenceNumber: 65535, Received: true},
+			{SequenceNumber: 0, Received: true},
+		})
+		assert.NoError(t, err)
+		twccFeedback, err := history.TransportLayerCC(twcc[0])
+		assert.NoError(t, err)
+		assert.Len(t, twccFeedback.Packets, 1)
+		assert.Equal(t, uint16(0), twccFeedback.Packets[0].TransportSequenceNumber)
+	})
+}
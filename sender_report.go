@@ -252,7 +252,7 @@ func (r *SenderReport) Header() Header {
 func (r SenderReport) String() string {
 	var out strings.Builder
 	fmt.Fprintf(&out, "SenderReport from %x\n", r.SSRC)
-	fmt.Fprintf(&out, "\tNTPTime:\t%d\n", r.NTPTime)
+	fmt.Fprintf(&out, "\tNTPTime:\t%d (%s)\n", r.NTPTime, NTPTime(r.NTPTime))
 	fmt.Fprintf(&out, "\tRTPTIme:\t%d\n", r.RTPTime)
 	fmt.Fprintf(&out, "\tPacketCount:\t%d\n", r.PacketCount)
 	fmt.Fprintf(&out, "\tOctetCount:\t%d\n", r.OctetCount)
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// SenderStats tracks the RTP packets sent for a single synchronization
+// source, and produces the SenderReport packets describing them.
+//
+// SenderStats is not safe for concurrent use.
+type SenderStats struct {
+	ssrc      uint32
+	clockRate uint32
+
+	started       bool
+	lastTimestamp uint32
+	lastSent      time.Time
+
+	// Counters are kept in 64 bits and wrap when reported, as allowed by
+	// RFC 3550 section 6.4.1.
+	packetCount uint64
+	octetCount  uint64
+}
+
+// NewSenderStats returns a SenderStats for the source with the given SSRC,
+// sending media with the given RTP clock rate.
+func NewSenderStats(ssrc, clockRate uint32) *SenderStats {
+	return &SenderStats{ssrc: ssrc, clockRate: clockRate}
+}
+
+// SendPacket records an RTP packet with the given timestamp, sent at the
+// given time. The payload size excludes the RTP header, header extensions,
+// CSRC list and padding, as those are not counted in the octet count.
+func (s *SenderStats) SendPacket(timestamp uint32, payloadSize int, sent time.Time) {
+	s.started = true
+	s.lastTimestamp = timestamp
+	s.lastSent = sent
+
+	s.packetCount++
+	if payloadSize > 0 {
+		s.octetCount += uint64(payloadSize)
+	}
+}
+
+// BuildSenderReport returns a SenderReport for the given time. Its RTPTime
+// corresponds to the same instant as its NTPTime, extrapolated from the
+// timestamp of the last packet sent using the clock rate. It returns false
+// if no packet has been sent yet, in which case a ReceiverReport should be
+// sent instead.
+func (s *SenderStats) BuildSenderReport(now time.Time) (SenderReport, bool) {
+	if !s.started {
+		return SenderReport{}, false
+	}
+
+	elapsed := now.Sub(s.lastSent)
+	elapsedUnits := int64(elapsed/time.Second)*int64(s.clockRate) +
+		int64(elapsed%time.Second)*int64(s.clockRate)/int64(time.Second)
+
+	return SenderReport{
+		SSRC:        s.ssrc,
+		NTPTime:     ntpTimestamp(now),
+		RTPTime:     s.lastTimestamp + uint32(elapsedUnits), //nolint:gosec // G115, wraps like RTP timestamps
+		PacketCount: uint32(s.packetCount),                  //nolint:gosec // G115, wraps as allowed by RFC 3550
+		OctetCount:  uint32(s.octetCount),                   //nolint:gosec // G115, wraps as allowed by RFC 3550
+	}, true
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSenderStats(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := NewSenderStats(0x902f9e2e, 90000)
+
+	_, ok := stats.BuildSenderReport(start)
+	assert.False(t, ok, "nothing sent yet")
+
+	stats.SendPacket(0xFFFFFF00, 1000, start)
+	stats.SendPacket(0xFFFFFF00, 500, start.Add(time.Millisecond))
+	// The RTP timestamp wraps around
+	stats.SendPacket(2744, 1200, start.Add(33*time.Millisecond))
+
+	report, ok := stats.BuildSenderReport(start.Add(133 * time.Millisecond))
+	assert.True(t, ok)
+	assert.Equal(t, SenderReport{
+		SSRC:        0x902f9e2e,
+		NTPTime:     0xed003780220c49ba,
+		RTPTime:     2744 + 9000,
+		PacketCount: 3,
+		OctetCount:  2700,
+	}, report)
+}
+
+func TestSenderStatsCounterWrap(t *testing.T) {
+	start := time.Unix(0, 0)
+	stats := NewSenderStats(1, 48000)
+	stats.packetCount = math.MaxUint32
+	stats.octetCount = math.MaxUint32 - 10
+
+	stats.SendPacket(0, 20, start)
+
+	report, ok := stats.BuildSenderReport(start)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0), report.PacketCount)
+	assert.Equal(t, uint32(9), report.OctetCount)
+}
@@ -0,0 +1,414 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// Members are timed out after this many RTCP intervals without
+	// activity, see RFC 3550 section 6.3.5.
+	sessionMemberTimeout = 5
+	// Senders become receivers after this many RTCP intervals without
+	// sending RTP packets.
+	sessionSenderTimeout = 2
+	// Number of packets after which a source is considered valid, unless
+	// its CNAME is received first, see RFC 3550 section 6.2.1.
+	sessionValidationPackets = 2
+	// Group size from which BYE reconsideration is applied, see RFC 3550
+	// section 6.3.7.
+	sessionBYEReconsiderationMembers = 50
+)
+
+// MemberEventType is the type of a MemberEvent.
+type MemberEventType int
+
+// Member event types.
+const (
+	// MemberJoined is reported when a source is validated.
+	MemberJoined MemberEventType = iota + 1
+	// MemberLeft is reported when a source sends a BYE packet.
+	MemberLeft
+	// MemberTimedOut is reported when a source is removed after a period
+	// of inactivity.
+	MemberTimedOut
+)
+
+// MemberEvent reports a change in the membership of a Session.
+type MemberEvent struct {
+	Type MemberEventType
+	SSRC uint32
+	// Reason given in the BYE packet, for MemberLeft events.
+	Reason string
+}
+
+// SessionMember describes a member of a Session.
+type SessionMember struct {
+	SSRC uint32
+	// CNAME from the last source description, if any.
+	CNAME string
+	// Sender is true if the member sent RTP packets or sender reports
+	// recently.
+	Sender bool
+	// LastActivity is the arrival time of the last RTP or RTCP packet.
+	LastActivity time.Time
+
+	validated bool
+	packets   int
+	lastSent  time.Time
+}
+
+// Session is the member table of an RTP session, as seen by the local
+// participant, and maintained as described in RFC 3550 section 6.3.
+// It ingests RTP arrivals and parsed RTCP packets, validates new sources,
+// tracks which members are senders, times out inactive members, and
+// processes BYE packets. The member and sender counts are kept up to date
+// in the IntervalCalculator returned by Calculator.
+//
+// Packets from the local SSRC are ignored.
+//
+// Session is not safe for concurrent use.
+type Session struct {
+	ssrc       uint32
+	config     IntervalConfig
+	calculator *IntervalCalculator
+	members    map[uint32]*SessionMember
+
+	leaving bool
+	byes    int
+}
+
+// NewSession returns a Session for the local participant with the given
+// SSRC, joining the session at the given time.
+func NewSession(ssrc uint32, config IntervalConfig, now time.Time) *Session {
+	return &Session{
+		ssrc:       ssrc,
+		config:     config,
+		calculator: NewIntervalCalculator(config, now),
+		members:    map[uint32]*SessionMember{},
+	}
+}
+
+// Calculator returns the IntervalCalculator scheduling RTCP packets for the
+// session.
+func (s *Session) Calculator() *IntervalCalculator {
+	return s.calculator
+}
+
+// Member returns the member with the given SSRC, if it has been validated.
+func (s *Session) Member(ssrc uint32) (SessionMember, bool) {
+	member, ok := s.members[ssrc]
+	if !ok || !member.validated {
+		return SessionMember{}, false
+	}
+
+	return *member, true
+}
+
+// Members returns the SSRCs of the validated members, in ascending order,
+// excluding the local participant.
+func (s *Session) Members() []uint32 {
+	return s.collect(func(*SessionMember) bool { return true })
+}
+
+// Senders returns the SSRCs of the validated members that are senders, in
+// ascending order, excluding the local participant.
+func (s *Session) Senders() []uint32 {
+	return s.collect(func(member *SessionMember) bool { return member.Sender })
+}
+
+// ReceiveRTP records the arrival of an RTP packet from the given source,
+// which makes it a sender.
+func (s *Session) ReceiveRTP(ssrc uint32, now time.Time) []MemberEvent {
+	if s.leaving || ssrc == s.ssrc {
+		return nil
+	}
+
+	member, events := s.activity(ssrc, now, true)
+	member.lastSent = now
+	member.Sender = true
+	s.updateCounts()
+
+	return events
+}
+
+// ReceiveRTCP records the arrival of a compound RTCP packet of the given
+// size in octets, including lower layer headers, and updates the member
+// table with its content.
+func (s *Session) ReceiveRTCP(packets []Packet, size int, now time.Time) []MemberEvent {
+	if s.leaving {
+		// Only BYE packets are counted during BYE reconsideration, and
+		// only they update the average RTCP packet size
+		byes := 0
+		for _, packet := range packets {
+			if _, ok := packet.(*Goodbye); ok {
+				byes++
+			}
+		}
+		if byes > 0 {
+			s.byes += byes
+			s.calculator.PacketReceived(size)
+			s.calculator.SetMembers(1+s.byes, 0)
+		}
+
+		return nil
+	}
+
+	s.calculator.PacketReceived(size)
+
+	// Sources are validated by the number of compound packets they sent
+	seen := map[uint32]bool{}
+	activity := func(ssrc uint32) (*SessionMember, []MemberEvent) {
+		counted := !seen[ssrc]
+		seen[ssrc] = true
+
+		return s.activity(ssrc, now, counted)
+	}
+
+	var events []MemberEvent
+	for _, packet := range packets {
+		switch packet := packet.(type) {
+		case *SenderReport:
+			if packet.SSRC != s.ssrc {
+				member, memberEvents := activity(packet.SSRC)
+				member.lastSent = now
+				member.Sender = true
+				events = append(events, memberEvents...)
+			}
+		case *SourceDescription:
+			for _, chunk := range packet.Chunks {
+				if chunk.Source != s.ssrc {
+					member, memberEvents := activity(chunk.Source)
+					events = append(events, memberEvents...)
+					events = append(events, s.receiveSourceDescription(member, chunk)...)
+				}
+			}
+		case *Goodbye:
+			events = append(events, s.receiveGoodbye(packet, now)...)
+		default:
+			if ssrc, ok := packetSenderSSRC(packet); ok && ssrc != s.ssrc {
+				_, memberEvents := activity(ssrc)
+				events = append(events, memberEvents...)
+			}
+		}
+	}
+	s.updateCounts()
+
+	return events
+}
+
+// Timeout removes the members that have been inactive for 5 RTCP
+// intervals, and turns senders that have not sent RTP packets for 2
+// intervals into receivers. It should be called whenever an RTCP packet is
+// sent, as described in RFC 3550 section 6.3.5. The interval is that of a
+// receiver, even if the local participant is a sender, and always uses the
+// 5 second minimum, regardless of ReducedMinimum and of the first packet
+// having been sent.
+func (s *Session) Timeout(now time.Time) []MemberEvent {
+	if s.leaving {
+		return nil
+	}
+
+	interval := s.calculator.deterministicInterval(false, rtcpMinTime)
+	memberDeadline := now.Add(-sessionMemberTimeout * interval)
+	senderDeadline := now.Add(-sessionSenderTimeout * interval)
+
+	var events []MemberEvent
+	removed := false
+	for _, ssrc := range s.sortedSSRCs() {
+		member := s.members[ssrc]
+		if member.LastActivity.Before(memberDeadline) {
+			delete(s.members, ssrc)
+			if member.validated {
+				events = append(events, MemberEvent{Type: MemberTimedOut, SSRC: ssrc})
+				removed = true
+			}
+
+			continue
+		}
+		if member.Sender && member.lastSent.Before(senderDeadline) {
+			member.Sender = false
+		}
+	}
+
+	if removed {
+		s.removeMembers(now)
+	} else {
+		s.updateCounts()
+	}
+
+	return events
+}
+
+// Leave prepares the local participant to leave the session by sending a
+// BYE packet of the given size in octets. It returns true if the BYE packet
+// may be sent immediately. Otherwise, BYE reconsideration is applied as
+// described in RFC 3550 section 6.3.7: the BYE packet must be sent once
+// Calculator().Expire returns true, and only BYE packets received in the
+// meantime are taken into account.
+func (s *Session) Leave(byeSize int, now time.Time) bool {
+	if s.leaving {
+		return false
+	}
+
+	if len(s.Members())+1 < sessionBYEReconsiderationMembers {
+		return true
+	}
+
+	s.leaving = true
+	s.byes = 0
+	config := s.config
+	config.InitialPacketSize = byeSize
+	s.calculator = NewIntervalCalculator(config, now)
+
+	return false
+}
+
+// receiveSourceDescription records the CNAME of a member, which validates it.
+func (s *Session) receiveSourceDescription(member *SessionMember, chunk SourceDescriptionChunk) []MemberEvent {
+	var events []MemberEvent
+	for _, item := range chunk.Items {
+		if item.Type == SDESCNAME {
+			member.CNAME = item.Text
+			if !member.validated {
+				member.validated = true
+				events = append(events, MemberEvent{Type: MemberJoined, SSRC: chunk.Source})
+			}
+		}
+	}
+
+	return events
+}
+
+func (s *Session) receiveGoodbye(bye *Goodbye, now time.Time) []MemberEvent {
+	var events []MemberEvent
+	for _, ssrc := range bye.Sources {
+		member, ok := s.members[ssrc]
+		if !ok {
+			continue
+		}
+
+		delete(s.members, ssrc)
+		if member.validated {
+			events = append(events, MemberEvent{Type: MemberLeft, SSRC: ssrc, Reason: bye.Reason})
+		}
+	}
+
+	if len(events) > 0 {
+		s.removeMembers(now)
+	}
+
+	return events
+}
+
+// activity records a packet from the given source, adding it to the member
+// table and validating it once enough packets have been counted.
+func (s *Session) activity(ssrc uint32, now time.Time, counted bool) (*SessionMember, []MemberEvent) {
+	member, ok := s.members[ssrc]
+	if !ok {
+		member = &SessionMember{SSRC: ssrc}
+		s.members[ssrc] = member
+	}
+	member.LastActivity = now
+	if counted {
+		member.packets++
+	}
+
+	if member.validated || member.packets < sessionValidationPackets {
+		return member, nil
+	}
+	member.validated = true
+
+	return member, []MemberEvent{{Type: MemberJoined, SSRC: ssrc}}
+}
+
+// updateCounts updates the member and sender counts of the calculator,
+// including the local participant.
+func (s *Session) updateCounts() {
+	members, senders := s.counts()
+	s.calculator.SetMembers(members, senders)
+}
+
+// removeMembers updates the member and sender counts of the calculator
+// after members left, applying reverse reconsideration.
+func (s *Session) removeMembers(now time.Time) {
+	members, senders := s.counts()
+	s.calculator.RemoveMembers(members, senders, now)
+}
+
+func (s *Session) counts() (int, int) {
+	members, senders := 1, 0
+	if s.calculator.weSent {
+		senders++
+	}
+	for _, member := range s.members {
+		if !member.validated {
+			continue
+		}
+		members++
+		if member.Sender {
+			senders++
+		}
+	}
+
+	return members, senders
+}
+
+func (s *Session) collect(include func(*SessionMember) bool) []uint32 {
+	var ssrcs []uint32
+	for _, ssrc := range s.sortedSSRCs() {
+		if member := s.members[ssrc]; member.validated && include(member) {
+			ssrcs = append(ssrcs, ssrc)
+		}
+	}
+
+	return ssrcs
+}
+
+func (s *Session) sortedSSRCs() []uint32 {
+	ssrcs := make([]uint32, 0, len(s.members))
+	for ssrc := range s.members {
+		ssrcs = append(ssrcs, ssrc)
+	}
+	slices.Sort(ssrcs)
+
+	return ssrcs
+}
+
+// packetSenderSSRC returns the SSRC of the sender of the given packet, for
+// the packet types that carry one.
+func packetSenderSSRC(packet Packet) (uint32, bool) { //nolint:cyclop
+	switch packet := packet.(type) {
+	case *SenderReport:
+		return packet.SSRC, true
+	case *ReceiverReport:
+		return packet.SSRC, true
+	case *ApplicationDefined:
+		return packet.SSRC, true
+	case *ExtendedReport:
+		return packet.SenderSSRC, true
+	case *TransportLayerNack:
+		return packet.SenderSSRC, true
+	case *TransportLayerCC:
+		return packet.SenderSSRC, true
+	case *CCFeedbackReport:
+		return packet.SenderSSRC, true
+	case *RapidResynchronizationRequest:
+		return packet.SenderSSRC, true
+	case *PictureLossIndication:
+		return packet.SenderSSRC, true
+	case *SliceLossIndication:
+		return packet.SenderSSRC, true
+	case *FullIntraRequest:
+		return packet.SenderSSRC, true
+	case *ReceiverEstimatedMaximumBitrate:
+		return packet.SenderSSRC, true
+	case *LossNotification:
+		return packet.SenderSSRC, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSession(now time.Time) *Session {
+	return NewSession(1, IntervalConfig{
+		SessionBandwidth:  1000000,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+}
+
+func TestSessionJoin(t *testing.T) {
+	now := time.Unix(1000, 0)
+	session := newTestSession(now)
+
+	// Validated by its CNAME
+	events := session.ReceiveRTCP([]Packet{
+		&ReceiverReport{SSRC: 2},
+		NewCNAMESourceDescription(2, "alice"),
+	}, 100, now)
+	assert.Equal(t, []MemberEvent{{Type: MemberJoined, SSRC: 2}}, events)
+
+	member, ok := session.Member(2)
+	assert.True(t, ok)
+	assert.Equal(t, "alice", member.CNAME)
+	assert.False(t, member.Sender)
+	assert.Equal(t, now, member.LastActivity)
+
+	// Validated after a second compound packet
+	pli := &PictureLossIndication{SenderSSRC: 3, MediaSSRC: 1}
+	events = session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 3}, pli}, 100, now)
+	assert.Empty(t, events)
+	_, ok = session.Member(3)
+	assert.False(t, ok)
+
+	events = session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 3}}, 100, now)
+	assert.Equal(t, []MemberEvent{{Type: MemberJoined, SSRC: 3}}, events)
+
+	// The local participant is not a member
+	events = session.ReceiveRTCP([]Packet{&SenderReport{SSRC: 1}, NewCNAMESourceDescription(1, "local")}, 100, now)
+	assert.Empty(t, events)
+
+	assert.Equal(t, []uint32{2, 3}, session.Members())
+	assert.Empty(t, session.Senders())
+	assert.Equal(t, 3, session.Calculator().members)
+}
+
+func TestSessionSenders(t *testing.T) {
+	now := time.Unix(1000, 0)
+	session := newTestSession(now)
+	session.Calculator().PacketSent(100, now)
+
+	session.ReceiveRTCP([]Packet{&SenderReport{SSRC: 2}, NewCNAMESourceDescription(2, "alice")}, 100, now)
+	session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 3}, NewCNAMESourceDescription(3, "bob")}, 100, now)
+	events := session.ReceiveRTP(4, now)
+	assert.Empty(t, events)
+	events = session.ReceiveRTP(4, now)
+	assert.Equal(t, []MemberEvent{{Type: MemberJoined, SSRC: 4}}, events)
+
+	assert.Equal(t, []uint32{2, 4}, session.Senders())
+	assert.Equal(t, 2, session.Calculator().senders)
+
+	// Senders become receivers after 2 intervals
+	later := now.Add(11 * time.Second)
+	session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 2}, &ReceiverReport{SSRC: 3}, &ReceiverReport{SSRC: 4}}, 100, later)
+	session.ReceiveRTP(4, later)
+	assert.Empty(t, session.Timeout(later))
+	assert.Equal(t, []uint32{4}, session.Senders())
+	assert.Equal(t, 1, session.Calculator().senders)
+}
+
+func TestSessionTimeout(t *testing.T) {
+	now := time.Unix(1000, 0)
+	session := newTestSession(now)
+	session.Calculator().PacketSent(100, now)
+
+	session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 2}, NewCNAMESourceDescription(2, "alice")}, 100, now)
+	session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 3}}, 100, now)
+
+	later := now.Add(20 * time.Second)
+	session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 4}, NewCNAMESourceDescription(4, "bob")}, 100, later)
+	assert.Empty(t, session.Timeout(later))
+
+	// Members are removed after 5 intervals, silently if never validated
+	later = now.Add(26 * time.Second)
+	assert.Equal(t, []MemberEvent{{Type: MemberTimedOut, SSRC: 2}}, session.Timeout(later))
+	assert.Equal(t, []uint32{4}, session.Members())
+	assert.Equal(t, 2, session.Calculator().members)
+}
+
+func TestSessionTimeoutAsSender(t *testing.T) {
+	now := time.Unix(1000, 0)
+	session := NewSession(1, IntervalConfig{SessionBandwidth: 16000, InitialPacketSize: 100, Random: noRandomization}, now)
+	session.Calculator().PacketSent(100, now)
+	session.Calculator().SetWeSent(true)
+
+	for ssrc := uint32(2); ssrc <= 10; ssrc++ {
+		session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: ssrc}, NewCNAMESourceDescription(ssrc, "member")}, 100, now)
+	}
+	assert.Equal(t, 5*time.Second, session.Calculator().DeterministicInterval())
+
+	// Members are timed out with the 12 second interval of a receiver
+	assert.Empty(t, session.Timeout(now.Add(59*time.Second)))
+	assert.Len(t, session.Timeout(now.Add(61*time.Second)), 9)
+}
+
+func TestSessionTimeoutReducedMinimum(t *testing.T) {
+	now := time.Unix(1000, 0)
+	session := NewSession(1, IntervalConfig{
+		SessionBandwidth:  10000000,
+		ReducedMinimum:    true,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+	session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 2}, NewCNAMESourceDescription(2, "alice")}, 100, now)
+	assert.True(t, session.Calculator().initial)
+
+	// Members are timed out after 5 times the 5 second minimum
+	assert.Empty(t, session.Timeout(now.Add(20*time.Second)))
+	assert.Equal(t, []uint32{2}, session.Members())
+
+	session.Calculator().PacketSent(100, now)
+	assert.Equal(t, 36*time.Millisecond, session.Calculator().DeterministicInterval())
+	assert.Empty(t, session.Timeout(now.Add(20*time.Second)))
+	assert.Equal(t, []MemberEvent{{Type: MemberTimedOut, SSRC: 2}}, session.Timeout(now.Add(26*time.Second)))
+}
+
+func TestSessionGoodbye(t *testing.T) {
+	now := time.Unix(1000, 0)
+	session := newTestSession(now)
+
+	for ssrc := uint32(2); ssrc <= 5; ssrc++ {
+		session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: ssrc}, NewCNAMESourceDescription(ssrc, "member")}, 100, now)
+	}
+	assert.Equal(t, 5, session.Calculator().members)
+	session.Calculator().PacketSent(100, now)
+
+	scheduled := session.Calculator().NextTransmission()
+	events := session.ReceiveRTCP([]Packet{
+		&ReceiverReport{SSRC: 2},
+		NewCNAMESourceDescription(2, "member"),
+		&Goodbye{Sources: []uint32{2, 3, 9}, Reason: "camera malfunction"},
+	}, 100, now)
+	assert.Equal(t, []MemberEvent{
+		{Type: MemberLeft, SSRC: 2, Reason: "camera malfunction"},
+		{Type: MemberLeft, SSRC: 3, Reason: "camera malfunction"},
+	}, events)
+	assert.Equal(t, []uint32{4, 5}, session.Members())
+	assert.Equal(t, 3, session.Calculator().members)
+
+	// Reverse reconsideration brings the next transmission forward
+	assert.True(t, session.Calculator().NextTransmission().Before(scheduled))
+}
+
+func TestSessionLeave(t *testing.T) {
+	now := time.Unix(1000, 0)
+	session := newTestSession(now)
+	assert.True(t, session.Leave(100, now), "small groups send BYE immediately")
+
+	chunks := make([]SourceDescriptionChunk, 0, 60)
+	for ssrc := uint32(2); ssrc < 62; ssrc++ {
+		chunks = append(chunks, SourceDescriptionChunk{
+			Source: ssrc,
+			Items:  []SourceDescriptionItem{{Type: SDESCNAME, Text: "member"}},
+		})
+	}
+	session.ReceiveRTCP([]Packet{&SourceDescription{Chunks: chunks}}, 1000, now)
+	assert.Equal(t, 61, session.Calculator().members)
+
+	assert.False(t, session.Leave(100, now))
+	assert.Equal(t, 1, session.Calculator().members)
+	assert.Equal(t, now.Add(compensated(2500*time.Millisecond)), session.Calculator().NextTransmission())
+
+	// Only BYE packets are counted, once per packet, and update the
+	// average packet size
+	events := session.ReceiveRTCP([]Packet{&ReceiverReport{SSRC: 70}, NewCNAMESourceDescription(70, "late")}, 1000, now)
+	assert.Empty(t, events)
+	assert.Empty(t, session.ReceiveRTP(71, now))
+	assert.Equal(t, 1, session.Calculator().members)
+	assert.InDelta(t, 100, session.Calculator().avgPacketSize, 0.001)
+
+	events = session.ReceiveRTCP([]Packet{
+		&ReceiverReport{SSRC: 2},
+		&Goodbye{Sources: []uint32{2, 3}},
+	}, 1700, now)
+	assert.Empty(t, events)
+	assert.Equal(t, 2, session.Calculator().members)
+	assert.InDelta(t, 200, session.Calculator().avgPacketSize, 0.001)
+
+	assert.False(t, session.Leave(100, now))
+}
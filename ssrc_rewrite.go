@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"fmt"
+)
+
+// SSRCChange records an SSRC changed by RewriteSSRCs.
+type SSRCChange struct {
+	// Field is the path of the field in the packet, such as "SenderSSRC" or
+	// "Reports[1].SSRC". Fields of the packets of a CompoundPacket are
+	// prefixed with the index of the packet, as in "[0].SSRC".
+	Field    string
+	Old, New uint32
+}
+
+type ssrcRewriter struct {
+	mapping func(uint32) uint32
+	// If set, the packet is only checked for unsupported types
+	validate bool
+	prefix   string
+	changes  []SSRCChange
+}
+
+// RewriteSSRCs replaces in place every SSRC carried by a packet, as sender,
+// media source or reported source, with the SSRC returned by mapping, and
+// returns the fields that changed. It is meant for translators and SFUs
+// forwarding RTCP between legs with different SSRCs. The mapping should
+// return the SSRCs it doesn't translate unchanged.
+//
+// RewriteSSRCs supports all the packet types and report blocks of this
+// package, including CompoundPacket. Those whose content it doesn't know
+// may carry SSRCs it can't find: it returns an error for RawPacket,
+// UnknownReportBlock, and the packets and report blocks registered with
+// RegisterPacket, RegisterApplicationLayerFeedback and RegisterReportBlock,
+// without changing the packet.
+func RewriteSSRCs(packet Packet, mapping func(uint32) uint32) ([]SSRCChange, error) {
+	if err := (&ssrcRewriter{validate: true}).packet(packet); err != nil {
+		return nil, err
+	}
+
+	rewriter := &ssrcRewriter{mapping: mapping}
+	if err := rewriter.packet(packet); err != nil {
+		return nil, err
+	}
+
+	return rewriter.changes, nil
+}
+
+func (r *ssrcRewriter) ssrc(field string, ssrc *uint32) {
+	if r.validate {
+		return
+	}
+	if mapped := r.mapping(*ssrc); mapped != *ssrc {
+		r.changes = append(r.changes, SSRCChange{Field: r.prefix + field, Old: *ssrc, New: mapped})
+		*ssrc = mapped
+	}
+}
+
+func (r *ssrcRewriter) receptionReports(reports []ReceptionReport) {
+	for i := range reports {
+		r.ssrc(fmt.Sprintf("Reports[%d].SSRC", i), &reports[i].SSRC)
+	}
+}
+
+func (r *ssrcRewriter) packet(packet Packet) error { //nolint:cyclop
+	switch packet := packet.(type) {
+	case *SenderReport:
+		r.ssrc("SSRC", &packet.SSRC)
+		r.receptionReports(packet.Reports)
+	case *ReceiverReport:
+		r.ssrc("SSRC", &packet.SSRC)
+		r.receptionReports(packet.Reports)
+	case *SourceDescription:
+		for i := range packet.Chunks {
+			r.ssrc(fmt.Sprintf("Chunks[%d].Source", i), &packet.Chunks[i].Source)
+		}
+	case *Goodbye:
+		for i := range packet.Sources {
+			r.ssrc(fmt.Sprintf("Sources[%d]", i), &packet.Sources[i])
+		}
+	case *ApplicationDefined:
+		r.ssrc("SSRC", &packet.SSRC)
+	case *ExtendedReport:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		for i, block := range packet.Reports {
+			if err := r.reportBlock(fmt.Sprintf("Reports[%d]", i), block); err != nil {
+				return err
+			}
+		}
+	case *TransportLayerNack:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		r.ssrc("MediaSSRC", &packet.MediaSSRC)
+	case *TransportLayerCC:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		r.ssrc("MediaSSRC", &packet.MediaSSRC)
+	case *CCFeedbackReport:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		for i := range packet.ReportBlocks {
+			r.ssrc(fmt.Sprintf("ReportBlocks[%d].MediaSSRC", i), &packet.ReportBlocks[i].MediaSSRC)
+		}
+	case *RapidResynchronizationRequest:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		r.ssrc("MediaSSRC", &packet.MediaSSRC)
+	case *PictureLossIndication:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		r.ssrc("MediaSSRC", &packet.MediaSSRC)
+	case *SliceLossIndication:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		r.ssrc("MediaSSRC", &packet.MediaSSRC)
+	case *FullIntraRequest:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		r.ssrc("MediaSSRC", &packet.MediaSSRC)
+		for i := range packet.FIR {
+			r.ssrc(fmt.Sprintf("FIR[%d].SSRC", i), &packet.FIR[i].SSRC)
+		}
+	case *ReceiverEstimatedMaximumBitrate:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		for i := range packet.SSRCs {
+			r.ssrc(fmt.Sprintf("SSRCs[%d]", i), &packet.SSRCs[i])
+		}
+	case *LossNotification:
+		r.ssrc("SenderSSRC", &packet.SenderSSRC)
+		r.ssrc("MediaSSRC", &packet.MediaSSRC)
+	case *CompoundPacket:
+		return r.compound(*packet)
+	default:
+		return fmt.Errorf("%w: %T", errSSRCRewriteUnsupported, packet)
+	}
+
+	return nil
+}
+
+func (r *ssrcRewriter) compound(compound CompoundPacket) error {
+	prefix := r.prefix
+	defer func() { r.prefix = prefix }()
+
+	for i, packet := range compound {
+		r.prefix = fmt.Sprintf("%s[%d].", prefix, i)
+		if err := r.packet(packet); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ssrcRewriter) reportBlock(field string, block ReportBlock) error { //nolint:cyclop
+	switch block := block.(type) {
+	case *LossRLEReportBlock:
+		r.ssrc(field+".SSRC", &block.SSRC)
+	case *DuplicateRLEReportBlock:
+		r.ssrc(field+".SSRC", &block.SSRC)
+	case *PacketReceiptTimesReportBlock:
+		r.ssrc(field+".SSRC", &block.SSRC)
+	case *DLRRReportBlock:
+		for i := range block.Reports {
+			r.ssrc(fmt.Sprintf("%s.Reports[%d].SSRC", field, i), &block.Reports[i].SSRC)
+		}
+	case *StatisticsSummaryReportBlock:
+		r.ssrc(field+".SSRC", &block.SSRC)
+	case *VoIPMetricsReportBlock:
+		r.ssrc(field+".SSRC", &block.SSRC)
+	case *LossConcealmentMetricsReportBlock:
+		r.ssrc(field+".SSRC", &block.SSRC)
+	case *ConcealedSecondsMetricsReportBlock:
+		r.ssrc(field+".SSRC", &block.SSRC)
+	case *MOSMetricsReportBlock:
+		r.ssrc(field+".SSRC", &block.SSRC)
+	case *ReceiverReferenceTimeReportBlock:
+		// Carries no SSRC
+	default:
+		return fmt.Errorf("%w: %T", errSSRCRewriteUnsupported, block)
+	}
+
+	return nil
+}
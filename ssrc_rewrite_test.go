@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteSSRCs(t *testing.T) {
+	// Translates SSRCs 1 to 9 to 101 to 109
+	mapping := func(ssrc uint32) uint32 {
+		if ssrc >= 1 && ssrc <= 9 {
+			return ssrc + 100
+		}
+
+		return ssrc
+	}
+
+	for _, test := range []struct {
+		name     string
+		packet   Packet
+		expected Packet
+		changes  []string
+	}{
+		{
+			name:     "ReceiverReport",
+			packet:   &ReceiverReport{SSRC: 1, Reports: []ReceptionReport{{SSRC: 20}, {SSRC: 2}}},
+			expected: &ReceiverReport{SSRC: 101, Reports: []ReceptionReport{{SSRC: 20}, {SSRC: 102}}},
+			changes:  []string{"SSRC", "Reports[1].SSRC"},
+		},
+		{
+			name:     "SenderReport",
+			packet:   &SenderReport{SSRC: 1, Reports: []ReceptionReport{{SSRC: 2}}},
+			expected: &SenderReport{SSRC: 101, Reports: []ReceptionReport{{SSRC: 102}}},
+			changes:  []string{"SSRC", "Reports[0].SSRC"},
+		},
+		{
+			name:     "Goodbye",
+			packet:   &Goodbye{Sources: []uint32{1, 2}, Reason: "bye"},
+			expected: &Goodbye{Sources: []uint32{101, 102}, Reason: "bye"},
+			changes:  []string{"Sources[0]", "Sources[1]"},
+		},
+		{
+			name:     "FullIntraRequest",
+			packet:   &FullIntraRequest{SenderSSRC: 1, FIR: []FIREntry{{SSRC: 2, SequenceNumber: 3}}},
+			expected: &FullIntraRequest{SenderSSRC: 101, FIR: []FIREntry{{SSRC: 102, SequenceNumber: 3}}},
+			changes:  []string{"SenderSSRC", "FIR[0].SSRC"},
+		},
+		{
+			name:     "ReceiverEstimatedMaximumBitrate",
+			packet:   &ReceiverEstimatedMaximumBitrate{SenderSSRC: 10, Bitrate: 1000, SSRCs: []uint32{1, 20}},
+			expected: &ReceiverEstimatedMaximumBitrate{SenderSSRC: 10, Bitrate: 1000, SSRCs: []uint32{101, 20}},
+			changes:  []string{"SSRCs[0]"},
+		},
+		{
+			name: "CCFeedbackReport",
+			packet: &CCFeedbackReport{
+				SenderSSRC:   1,
+				ReportBlocks: []CCFeedbackReportBlock{{MediaSSRC: 2, BeginSequence: 3}},
+			},
+			expected: &CCFeedbackReport{
+				SenderSSRC:   101,
+				ReportBlocks: []CCFeedbackReportBlock{{MediaSSRC: 102, BeginSequence: 3}},
+			},
+			changes: []string{"SenderSSRC", "ReportBlocks[0].MediaSSRC"},
+		},
+		{
+			name: "ExtendedReport",
+			packet: &ExtendedReport{
+				SenderSSRC: 1,
+				Reports: []ReportBlock{
+					&ReceiverReferenceTimeReportBlock{NTPTimestamp: 1},
+					&DLRRReportBlock{Reports: []DLRRReport{{SSRC: 20}, {SSRC: 2}}},
+					&LossRLEReportBlock{SSRC: 3},
+				},
+			},
+			expected: &ExtendedReport{
+				SenderSSRC: 101,
+				Reports: []ReportBlock{
+					&ReceiverReferenceTimeReportBlock{NTPTimestamp: 1},
+					&DLRRReportBlock{Reports: []DLRRReport{{SSRC: 20}, {SSRC: 102}}},
+					&LossRLEReportBlock{SSRC: 103},
+				},
+			},
+			changes: []string{"SenderSSRC", "Reports[1].Reports[1].SSRC", "Reports[2].SSRC"},
+		},
+		{
+			name: "CompoundPacket",
+			packet: &CompoundPacket{
+				&ReceiverReport{SSRC: 1},
+				NewCNAMESourceDescription(1, "cname"),
+				&TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2},
+				&TransportLayerCC{SenderSSRC: 1, MediaSSRC: 20},
+				&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2},
+			},
+			expected: &CompoundPacket{
+				&ReceiverReport{SSRC: 101},
+				NewCNAMESourceDescription(101, "cname"),
+				&TransportLayerNack{SenderSSRC: 101, MediaSSRC: 102},
+				&TransportLayerCC{SenderSSRC: 101, MediaSSRC: 20},
+				&PictureLossIndication{SenderSSRC: 101, MediaSSRC: 102},
+			},
+			changes: []string{
+				"[0].SSRC", "[1].Chunks[0].Source", "[2].SenderSSRC", "[2].MediaSSRC",
+				"[3].SenderSSRC", "[4].SenderSSRC", "[4].MediaSSRC",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := RewriteSSRCs(test.packet, mapping)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, test.packet)
+
+			var fields []string
+			for _, change := range changes {
+				assert.Equal(t, mapping(change.Old), change.New)
+				fields = append(fields, change.Field)
+			}
+			assert.Equal(t, test.changes, fields)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := RewriteSSRCs(&RawPacket{}, mapping)
+		assert.ErrorIs(t, err, errSSRCRewriteUnsupported)
+
+		// Nothing is rewritten
+		compound := &CompoundPacket{&ReceiverReport{SSRC: 1}, &RawPacket{}}
+		_, err = RewriteSSRCs(compound, mapping)
+		assert.ErrorIs(t, err, errSSRCRewriteUnsupported)
+		assert.Equal(t, &CompoundPacket{&ReceiverReport{SSRC: 1}, &RawPacket{}}, compound)
+
+		report := &ExtendedReport{
+			SenderSSRC: 1,
+			Reports:    []ReportBlock{&LossRLEReportBlock{SSRC: 2}, &UnknownReportBlock{}},
+		}
+		_, err = RewriteSSRCs(report, mapping)
+		assert.ErrorIs(t, err, errSSRCRewriteUnsupported)
+		assert.Equal(t, uint32(1), report.SenderSSRC)
+		assert.Equal(t, &LossRLEReportBlock{SSRC: 2}, report.Reports[0])
+	})
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// Minimum average time between RTCP packets, see RFC 3550 section 6.2.
+	rtcpMinTime = 5 * time.Second
+	// Minimum interval before the first RTCP packet in AVPF mode, see
+	// RFC 4585 section 3.4.
+	avpfInitialMinTime = time.Second
+	// Fraction of the session bandwidth allotted to RTCP.
+	rtcpBandwidthFraction = 0.05
+	// Fraction of the RTCP bandwidth shared by active senders.
+	rtcpSenderBandwidthFraction = 0.25
+	// Fraction of the RTCP bandwidth shared by receivers.
+	rtcpReceiverBandwidthFraction = 1 - rtcpSenderBandwidthFraction
+	// To compensate for "timer reconsideration" converging to a value below
+	// the intended average, see RFC 3550 A.7.
+	rtcpCompensation = math.E - 1.5
+)
+
+// IntervalConfig configures an IntervalCalculator.
+type IntervalConfig struct {
+	// SessionBandwidth is the bandwidth of the RTP session in bits per
+	// second. 5% of it is allotted to RTCP.
+	SessionBandwidth uint64
+
+	// ReducedMinimum replaces the 5 second minimum interval with
+	// 360 divided by the session bandwidth in kilobits per second, as
+	// allowed by RFC 3550 section 6.2. It doesn't apply before the first
+	// packet is sent, when half of the 5 second minimum is used instead.
+	ReducedMinimum bool
+
+	// AVPF applies the minimum interval of the Extended RTP Profile for
+	// RTCP-Based Feedback, RFC 4585 section 3.4, instead of the RFC 3550
+	// one: 1 second before the first packet is sent and none afterwards,
+	// regular RTCP packets being limited by T_rr_interval instead. It
+	// replaces ReducedMinimum, and is ignored if SessionBandwidth is zero.
+	// FeedbackScheduler always sets it.
+	AVPF bool
+
+	// InitialPacketSize is the probable size in octets, including lower
+	// layer headers, of the first compound RTCP packet that will be sent.
+	// It seeds the average packet size.
+	InitialPacketSize int
+
+	// Random returns a pseudo-random number in [0.0, 1.0) used to
+	// randomize the interval. It defaults to math/rand/v2.Float64, and may
+	// be replaced for deterministic tests.
+	Random func() float64
+}
+
+// IntervalCalculator computes when RTCP packets should be sent in a session,
+// using the algorithm described in RFC 3550 section 6.3 and Appendix A.7:
+// the RTCP bandwidth is split between senders and receivers, the interval
+// is randomized and bounded by a minimum, and timer reconsideration and
+// reverse reconsideration adapt the schedule as the group size changes.
+//
+// Packet sizes are those of compound RTCP packets, as returned by
+// MarshalSize, plus the size of the lower layer headers (28 octets for
+// UDP over IPv4).
+//
+// IntervalCalculator is not safe for concurrent use.
+type IntervalCalculator struct {
+	config IntervalConfig
+
+	members        int
+	pmembers       int
+	senders        int
+	weSent         bool
+	initial        bool
+	avgPacketSize  float64
+	lastSent       time.Time
+	nextScheduled  time.Time
+	rtcpBandwidth  float64
+	reducedMinimum time.Duration
+}
+
+// NewIntervalCalculator returns an IntervalCalculator for a participant
+// joining the session at the given time, and schedules its first
+// transmission.
+func NewIntervalCalculator(config IntervalConfig, now time.Time) *IntervalCalculator {
+	if config.Random == nil {
+		config.Random = rand.Float64 //nolint:gosec // G404, randomization does not need to be secure
+	}
+
+	calculator := &IntervalCalculator{
+		config:        config,
+		members:       1,
+		pmembers:      1,
+		initial:       true,
+		avgPacketSize: float64(config.InitialPacketSize),
+		lastSent:      now,
+		// In octets per second
+		rtcpBandwidth: float64(config.SessionBandwidth) * rtcpBandwidthFraction / 8,
+	}
+	if config.ReducedMinimum && config.SessionBandwidth > 0 {
+		calculator.reducedMinimum = time.Duration(360 * float64(time.Second) / (float64(config.SessionBandwidth) / 1000))
+	}
+	calculator.nextScheduled = now.Add(calculator.Interval())
+
+	return calculator
+}
+
+// SetMembers updates the number of members and active senders in the
+// session, including this participant. Use RemoveMembers instead when the
+// number of members decreases because members left or timed out.
+func (c *IntervalCalculator) SetMembers(members, senders int) {
+	c.members = max(members, 1)
+	c.senders = max(senders, 0)
+}
+
+// SetWeSent records whether this participant sent RTP data since the
+// second previous RTCP report, and is therefore counted as a sender.
+func (c *IntervalCalculator) SetWeSent(weSent bool) {
+	c.weSent = weSent
+}
+
+// RemoveMembers updates the number of members and active senders in the
+// session after members left or timed out, and applies reverse
+// reconsideration as described in RFC 3550 section 6.3.4, so that the
+// next transmission is brought forward in proportion to the group size.
+func (c *IntervalCalculator) RemoveMembers(members, senders int, now time.Time) {
+	c.members = max(members, 1)
+	c.senders = max(senders, 0)
+
+	if c.members < c.pmembers {
+		ratio := float64(c.members) / float64(c.pmembers)
+		c.nextScheduled = now.Add(time.Duration(ratio * float64(c.nextScheduled.Sub(now))))
+		c.lastSent = now.Add(-time.Duration(ratio * float64(now.Sub(c.lastSent))))
+		c.pmembers = c.members
+	}
+}
+
+// PacketReceived updates the average compound packet size with a received
+// RTCP packet of the given size in octets.
+func (c *IntervalCalculator) PacketReceived(size int) {
+	c.updateAverage(size)
+}
+
+// PacketSent records that a compound RTCP packet of the given size in
+// octets was sent at the given time, and schedules the next transmission.
+func (c *IntervalCalculator) PacketSent(size int, now time.Time) {
+	c.updateAverage(size)
+	c.initial = false
+	c.lastSent = now
+	c.pmembers = c.members
+	c.nextScheduled = now.Add(c.Interval())
+}
+
+// NextTransmission returns the time at which the next RTCP packet is
+// scheduled. Expire should be called at that time.
+func (c *IntervalCalculator) NextTransmission() time.Time {
+	return c.nextScheduled
+}
+
+// Expire applies timer reconsideration when the transmission timer fires,
+// as described in RFC 3550 section 6.3.6. It returns true if an RTCP
+// packet should be sent now, in which case PacketSent must be called once
+// it has been sent. Otherwise, the transmission has been rescheduled to
+// NextTransmission.
+func (c *IntervalCalculator) Expire(now time.Time) bool {
+	next := c.lastSent.Add(c.Interval())
+	if next.After(now) {
+		c.nextScheduled = next
+
+		return false
+	}
+
+	return true
+}
+
+// DeterministicInterval returns the calculated interval before
+// randomization, used for example to time out inactive members.
+func (c *IntervalCalculator) DeterministicInterval() time.Duration {
+	minimum := rtcpMinTime
+	switch avpf := c.config.AVPF && c.rtcpBandwidth > 0; {
+	case avpf && c.initial:
+		minimum = avpfInitialMinTime
+	case avpf:
+		minimum = 0
+	case c.initial:
+		minimum /= 2
+	case c.reducedMinimum > 0:
+		minimum = c.reducedMinimum
+	}
+
+	return c.deterministicInterval(c.weSent, minimum)
+}
+
+func (c *IntervalCalculator) deterministicInterval(weSent bool, minimum time.Duration) time.Duration {
+	bandwidth := c.rtcpBandwidth
+	members := c.members
+	// Dedicate a share of the bandwidth to senders, unless they are
+	// numerous enough to get a fair share anyway
+	if float64(c.senders) <= float64(c.members)*rtcpSenderBandwidthFraction {
+		if weSent {
+			bandwidth *= rtcpSenderBandwidthFraction
+			members = c.senders
+		} else {
+			bandwidth *= rtcpReceiverBandwidthFraction
+			members -= c.senders
+		}
+	}
+
+	interval := minimum
+	if bandwidth > 0 {
+		computed := time.Duration(c.avgPacketSize * float64(members) / bandwidth * float64(time.Second))
+		interval = max(interval, computed)
+	}
+
+	return interval
+}
+
+// Interval returns a newly randomized transmission interval, between 0.5
+// and 1.5 times the deterministic interval, divided by the compensation
+// factor for timer reconsideration.
+func (c *IntervalCalculator) Interval() time.Duration {
+	interval := float64(c.DeterministicInterval()) * (c.config.Random() + 0.5)
+
+	return time.Duration(interval / rtcpCompensation)
+}
+
+func (c *IntervalCalculator) updateAverage(size int) {
+	c.avgPacketSize = float64(size)/16 + c.avgPacketSize*15/16
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Makes the randomized interval equal to the deterministic one.
+func noRandomization() float64 {
+	return 0.5
+}
+
+func compensated(interval time.Duration) time.Duration {
+	return time.Duration(float64(interval) / rtcpCompensation)
+}
+
+func assertIntervalInDelta(t *testing.T, expected float64, actual time.Duration) {
+	t.Helper()
+
+	assert.InDelta(t, expected*float64(time.Second), float64(actual), float64(time.Microsecond))
+}
+
+func TestIntervalCalculatorMinimum(t *testing.T) {
+	now := time.Unix(1000, 0)
+	calculator := NewIntervalCalculator(IntervalConfig{
+		SessionBandwidth:  1000000,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+
+	// Half the minimum applies before the first packet is sent
+	assert.Equal(t, 2500*time.Millisecond, calculator.DeterministicInterval())
+	assert.Equal(t, now.Add(compensated(2500*time.Millisecond)), calculator.NextTransmission())
+
+	assert.True(t, calculator.Expire(calculator.NextTransmission()))
+	calculator.PacketSent(100, calculator.NextTransmission())
+	assert.Equal(t, 5*time.Second, calculator.DeterministicInterval())
+}
+
+func TestIntervalCalculatorReducedMinimum(t *testing.T) {
+	now := time.Unix(1000, 0)
+	calculator := NewIntervalCalculator(IntervalConfig{
+		SessionBandwidth:  1000000,
+		ReducedMinimum:    true,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+
+	assert.Equal(t, 2500*time.Millisecond, calculator.DeterministicInterval())
+	calculator.PacketSent(100, now)
+	assert.Equal(t, 360*time.Millisecond, calculator.DeterministicInterval())
+}
+
+func TestIntervalCalculatorAVPF(t *testing.T) {
+	now := time.Unix(1000, 0)
+	calculator := NewIntervalCalculator(IntervalConfig{
+		// 62500 octets per second for RTCP
+		SessionBandwidth:  10000000,
+		ReducedMinimum:    true,
+		AVPF:              true,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+
+	calculator.SetMembers(2, 1)
+
+	assert.Equal(t, time.Second, calculator.DeterministicInterval())
+	calculator.PacketSent(100, now)
+	assert.Equal(t, 3200*time.Microsecond, calculator.DeterministicInterval())
+}
+
+func TestIntervalCalculatorBandwidthSplit(t *testing.T) {
+	now := time.Unix(1000, 0)
+	calculator := NewIntervalCalculator(IntervalConfig{
+		// 1000 octets per second for RTCP
+		SessionBandwidth:  160000,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+	calculator.PacketSent(100, now)
+
+	// 1000 receivers share 750 octets per second
+	calculator.SetMembers(1000, 10)
+	assertIntervalInDelta(t, 100.0*990/750, calculator.DeterministicInterval())
+
+	// 10 senders share 250 octets per second
+	calculator.SetWeSent(true)
+	assert.Equal(t, 5*time.Second, calculator.DeterministicInterval())
+	calculator.SetMembers(1000, 100)
+	assert.Equal(t, 40*time.Second, calculator.DeterministicInterval())
+
+	// Senders are numerous enough for an even split
+	calculator.SetMembers(1000, 500)
+	assert.Equal(t, 100*time.Second, calculator.DeterministicInterval())
+}
+
+func TestIntervalCalculatorAveragePacketSize(t *testing.T) {
+	now := time.Unix(1000, 0)
+	calculator := NewIntervalCalculator(IntervalConfig{
+		SessionBandwidth:  160000,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+	calculator.PacketSent(100, now)
+	calculator.SetMembers(1000, 0)
+
+	calculator.PacketReceived(260)
+	assertIntervalInDelta(t, 110.0*1000/750, calculator.DeterministicInterval())
+}
+
+func TestIntervalCalculatorRandomization(t *testing.T) {
+	now := time.Unix(1000, 0)
+	random := 0.0
+	calculator := NewIntervalCalculator(IntervalConfig{
+		SessionBandwidth:  1000000,
+		InitialPacketSize: 100,
+		Random:            func() float64 { return random },
+	}, now)
+	calculator.PacketSent(100, now)
+
+	assert.Equal(t, compensated(2500*time.Millisecond), calculator.Interval())
+	random = 0.999
+	assert.InDelta(t,
+		float64(compensated(7500*time.Millisecond)), float64(calculator.Interval()), float64(5*time.Millisecond))
+
+	// The default source of randomness stays within bounds
+	calculator = NewIntervalCalculator(IntervalConfig{SessionBandwidth: 1000000, InitialPacketSize: 100}, now)
+	for i := 0; i < 100; i++ {
+		interval := calculator.Interval()
+		assert.GreaterOrEqual(t, interval, compensated(1250*time.Millisecond))
+		assert.Less(t, interval, compensated(3750*time.Millisecond))
+	}
+}
+
+func TestIntervalCalculatorTimerReconsideration(t *testing.T) {
+	now := time.Unix(1000, 0)
+	calculator := NewIntervalCalculator(IntervalConfig{
+		SessionBandwidth:  160000,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+	calculator.PacketSent(100, now)
+	scheduled := calculator.NextTransmission()
+
+	// Many members joined since the transmission was scheduled
+	calculator.SetMembers(100, 0)
+	assert.False(t, calculator.Expire(scheduled))
+	rescheduled := calculator.NextTransmission()
+	assertIntervalInDelta(t, 100.0*100/750/rtcpCompensation, rescheduled.Sub(now))
+
+	assert.True(t, calculator.Expire(rescheduled))
+}
+
+func TestIntervalCalculatorReverseReconsideration(t *testing.T) {
+	now := time.Unix(1000, 0)
+	calculator := NewIntervalCalculator(IntervalConfig{
+		SessionBandwidth:  160000,
+		InitialPacketSize: 100,
+		Random:            noRandomization,
+	}, now)
+	calculator.SetMembers(100, 0)
+	calculator.PacketSent(100, now)
+	scheduled := calculator.NextTransmission()
+
+	// Half the members leave a quarter of the way through the interval
+	current := now.Add(scheduled.Sub(now) / 4)
+	calculator.RemoveMembers(50, 0, current)
+	assert.Equal(t, current.Add(scheduled.Sub(current)/2), calculator.NextTransmission())
+
+	// Members joining don't trigger reverse reconsideration
+	next := calculator.NextTransmission()
+	calculator.SetMembers(80, 0)
+	calculator.RemoveMembers(60, 0, current)
+	assert.Equal(t, next, calculator.NextTransmission())
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 )
 
 // https://tools.ietf.org/html/draft-holmer-rmcat-transport-wide-cc-extensions-01#page-5
@@ -317,6 +318,10 @@ const (
 	referenceTimeOffset      = 12
 	fbPktCountOffset         = 15
 	packetChunkOffset        = 16
+
+	// Resolution of the reference time, in microseconds.
+	tccReferenceTimeUnit = 64000
+	tccReferenceTimeMask = (1 << 24) - 1
 )
 
 // TransportLayerCC for sender-BWE
@@ -578,6 +583,44 @@ func (t TransportLayerCC) DestinationSSRC() []uint32 {
 	return []uint32{t.MediaSSRC}
 }
 
+// Arrivals returns the status of each packet reported, in order of sequence
+// number. The arrival times of received packets are relative to the same
+// origin as the ReferenceTime, that is ReferenceTime multiplied by 64ms plus
+// the receive deltas.
+func (t TransportLayerCC) Arrivals() ([]TransportLayerCCArrival, error) {
+	arrivals := make([]TransportLayerCCArrival, 0, t.PacketStatusCount)
+	arrival := time.Duration(t.ReferenceTime) * tccReferenceTimeStep
+	sequenceNumber := t.BaseSequenceNumber
+	deltas := t.RecvDeltas
+
+	for _, chunk := range t.PacketChunks {
+		for _, symbol := range tccChunkSymbols(chunk, int(t.PacketStatusCount)-len(arrivals)) {
+			status := TransportLayerCCArrival{SequenceNumber: sequenceNumber}
+			switch symbol {
+			case TypeTCCPacketReceivedSmallDelta, TypeTCCPacketReceivedLargeDelta:
+				if len(deltas) == 0 {
+					return nil, errTCCDeltasMismatch
+				}
+				arrival += time.Duration(deltas[0].Delta) * time.Microsecond
+				deltas = deltas[1:]
+				status.Received = true
+				status.ArrivalTime = arrival
+			case TypeTCCPacketReceivedWithoutDelta:
+				status.Received = true
+				status.WithoutDelta = true
+			}
+			arrivals = append(arrivals, status)
+			sequenceNumber++
+		}
+	}
+
+	if len(arrivals) != int(t.PacketStatusCount) || len(deltas) != 0 {
+		return nil, errTCCDeltasMismatch
+	}
+
+	return arrivals, nil
+}
+
 func localMin(x, y uint16) uint16 {
 	if x < y {
 		return x
@@ -585,3 +628,67 @@ func localMin(x, y uint16) uint16 {
 
 	return y
 }
+
+func setTransportLayerCCHeader(cc *TransportLayerCC) {
+	cc.Header = Header{
+		Padding: cc.packetLen()%4 != 0,
+		Count:   FormatTCC,
+		Type:    TypeTransportSpecificFeedback,
+		Length:  uint16(cc.MarshalSize()/4 - 1), //nolint:gosec // G115
+	}
+}
+
+// tccChunkSymbols returns the status symbols of the packets described by a
+// chunk, at most limit.
+func tccChunkSymbols(chunk PacketStatusChunk, limit int) []uint16 {
+	switch chunk := chunk.(type) {
+	case *RunLengthChunk:
+		return tccRunSymbols(chunk.PacketStatusSymbol, min(int(chunk.RunLength), limit))
+	case *StatusVectorChunk:
+		return chunk.SymbolList[:min(len(chunk.SymbolList), limit)]
+	default:
+		return nil
+	}
+}
+
+func tccRunSymbols(symbol uint16, count int) []uint16 {
+	symbols := make([]uint16, count)
+	for i := range symbols {
+		symbols[i] = symbol
+	}
+
+	return symbols
+}
+
+func tccRunLength(chunk PacketStatusChunk) (*RunLengthChunk, bool) {
+	runLength, ok := chunk.(*RunLengthChunk)
+
+	return runLength, ok
+}
+
+func tccSymbolHasDelta(symbol uint16) bool {
+	return symbol == TypeTCCPacketReceivedSmallDelta || symbol == TypeTCCPacketReceivedLargeDelta
+}
+
+func tccDeltaLength(symbols []uint16) int {
+	length := 0
+	for _, symbol := range symbols {
+		switch symbol {
+		case TypeTCCPacketReceivedSmallDelta:
+			length++
+		case TypeTCCPacketReceivedLargeDelta:
+			length += 2
+		}
+	}
+
+	return length
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+
+	return q
+}
@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	tccMaxRunLength       = (1 << 13) - 1
+	tccOneBitVectorLength = 14
+	tccTwoBitVectorLength = 7
+	// Largest packet allowed by the header length field.
+	tccMaxPacketLength = (math.MaxUint16 + 1) * 4
+
+	tccDeltaUnit         = TypeTCCDeltaScaleFactor * time.Microsecond
+	tccReferenceTimeStep = tccReferenceTimeUnit * time.Microsecond
+)
+
+// TransportLayerCCArrival records the arrival of a packet, identified by its
+// transport-wide sequence number.
+type TransportLayerCCArrival struct {
+	SequenceNumber uint16
+	// Received is false if the packet was lost.
+	Received bool
+	// ArrivalTime of a received packet on the receiver's clock, relative
+	// to an arbitrary origin.
+	ArrivalTime time.Duration
+	// WithoutDelta is true if the packet was received but its arrival time
+	// is not reported.
+	WithoutDelta bool
+}
+
+// TransportLayerCCBuilder builds TransportLayerCC feedback packets for a media
+// source from packet arrivals, choosing the packet status chunks and receive
+// delta sizes, and numbering the packets with FbPktCount.
+//
+// TransportLayerCCBuilder is not safe for concurrent use.
+type TransportLayerCCBuilder struct {
+	senderSSRC uint32
+	mediaSSRC  uint32
+	maxSize    int
+	fbPktCount uint8
+}
+
+// NewTransportLayerCCBuilder returns a TransportLayerCCBuilder for feedback
+// sent by senderSSRC about mediaSSRC, with packets of at most maxSize octets.
+// A maxSize of zero only limits packets to the largest size that can be
+// encoded.
+func NewTransportLayerCCBuilder(senderSSRC, mediaSSRC uint32, maxSize int) *TransportLayerCCBuilder {
+	if maxSize <= 0 || maxSize > tccMaxPacketLength {
+		maxSize = tccMaxPacketLength
+	}
+
+	return &TransportLayerCCBuilder{
+		senderSSRC: senderSSRC,
+		mediaSSRC:  mediaSSRC,
+		maxSize:    maxSize,
+	}
+}
+
+type tccStatus struct {
+	received     bool
+	withoutDelta bool
+	arrival      time.Duration
+}
+
+// Build returns the TransportLayerCC packets reporting the given arrivals,
+// in any order. All the packets between the lowest and highest sequence
+// numbers are reported, those without an arrival as lost, and those
+// received WithoutDelta with the matching symbol. A new packet is
+// started when a receive delta doesn't fit in a large delta, or when the
+// size limit is reached.
+func (b *TransportLayerCCBuilder) Build(arrivals []TransportLayerCCArrival) ([]*TransportLayerCC, error) {
+	if len(arrivals) == 0 {
+		return nil, nil
+	}
+
+	baseSequenceNumber, statuses := tccStatuses(arrivals)
+
+	var packets []*TransportLayerCC
+	for len(statuses) > 0 {
+		packet, count := b.buildPacket(baseSequenceNumber, statuses)
+		if count == 0 {
+			return nil, errTCCSizeTooSmall
+		}
+		b.fbPktCount++
+
+		packets = append(packets, packet)
+		baseSequenceNumber += uint16(count) //nolint:gosec // G115
+		statuses = statuses[count:]
+	}
+
+	return packets, nil
+}
+
+// buildPacket returns a packet reporting as many of the given statuses as
+// possible, and their number.
+func (b *TransportLayerCCBuilder) buildPacket(
+	baseSequenceNumber uint16,
+	statuses []tccStatus,
+) (*TransportLayerCC, int) {
+	var reference int64
+	for _, status := range statuses {
+		if status.received && !status.withoutDelta {
+			reference = floorDiv(int64(status.arrival), int64(tccReferenceTimeStep))
+
+			break
+		}
+	}
+
+	// Receive deltas are computed between quantized arrival times, so that
+	// errors don't accumulate
+	previous := reference * int64(tccReferenceTimeStep/tccDeltaUnit)
+	symbols := make([]uint16, 0, len(statuses))
+	var deltas []*RecvDelta
+	for _, status := range statuses[:min(len(statuses), math.MaxUint16)] {
+		if !status.received || status.withoutDelta {
+			symbols = append(symbols, tccStatusSymbol(status))
+
+			continue
+		}
+
+		arrival := floorDiv(int64(status.arrival), int64(tccDeltaUnit))
+		delta := arrival - previous
+		symbol := TypeTCCPacketReceivedSmallDelta
+		switch {
+		case delta >= 0 && delta <= math.MaxUint8:
+		case delta >= math.MinInt16 && delta <= math.MaxInt16:
+			symbol = TypeTCCPacketReceivedLargeDelta
+		default:
+			// Continued in another packet with a new reference time
+			return b.truncatePacket(baseSequenceNumber, reference, symbols, deltas)
+		}
+
+		symbols = append(symbols, symbol)
+		deltas = append(deltas, &RecvDelta{Type: symbol, Delta: delta * TypeTCCDeltaScaleFactor})
+		previous = arrival
+	}
+
+	return b.truncatePacket(baseSequenceNumber, reference, symbols, deltas)
+}
+
+// truncatePacket returns a packet with the longest prefix of the given
+// statuses that fits in the size limit, and the length of that prefix.
+func (b *TransportLayerCCBuilder) truncatePacket(
+	baseSequenceNumber uint16,
+	reference int64,
+	symbols []uint16,
+	deltas []*RecvDelta,
+) (*TransportLayerCC, int) {
+	count := b.fitCount(symbols)
+	received := 0
+	for _, symbol := range symbols[:count] {
+		if tccSymbolHasDelta(symbol) {
+			received++
+		}
+	}
+
+	packet := &TransportLayerCC{
+		SenderSSRC:         b.senderSSRC,
+		MediaSSRC:          b.mediaSSRC,
+		BaseSequenceNumber: baseSequenceNumber,
+		PacketStatusCount:  uint16(count),                            //nolint:gosec // G115
+		ReferenceTime:      uint32(reference) & tccReferenceTimeMask, //nolint:gosec // G115
+		FbPktCount:         b.fbPktCount,
+		PacketChunks:       tccEncodeChunks(symbols[:count]),
+		RecvDeltas:         deltas[:received],
+	}
+	setTransportLayerCCHeader(packet)
+
+	return packet, count
+}
+
+// fitCount returns the number of leading statuses that fit in the size
+// limit once encoded.
+func (b *TransportLayerCCBuilder) fitCount(symbols []uint16) int {
+	fits := func(count int) bool {
+		length := headerLength + packetChunkOffset +
+			len(tccEncodeChunks(symbols[:count]))*packetStatusChunkLength + tccDeltaLength(symbols[:count])
+
+		return length+getPadding(length) <= b.maxSize
+	}
+
+	if fits(len(symbols)) {
+		return len(symbols)
+	}
+
+	// The encoded size grows with the number of statuses
+	low, high := 0, len(symbols)
+	for low < high {
+		mid := (low + high + 1) / 2
+		if fits(mid) {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+
+	return low
+}
+
+// tccStatuses returns the status of every packet from the lowest to the
+// highest sequence number of the given arrivals, and that lowest number.
+func tccStatuses(arrivals []TransportLayerCCArrival) (uint16, []tccStatus) {
+	first := arrivals[0].SequenceNumber
+	lowest, highest := 0, 0
+	for _, arrival := range arrivals {
+		offset := int(int16(arrival.SequenceNumber - first)) //nolint:gosec // G115, wraps around
+		lowest = min(lowest, offset)
+		highest = max(highest, offset)
+	}
+
+	statuses := make([]tccStatus, highest-lowest+1)
+	for _, arrival := range arrivals {
+		offset := int(int16(arrival.SequenceNumber-first)) - lowest //nolint:gosec // G115
+		if arrival.Received && (!statuses[offset].received || statuses[offset].withoutDelta) {
+			statuses[offset] = tccStatus{received: true, withoutDelta: arrival.WithoutDelta, arrival: arrival.ArrivalTime}
+		}
+	}
+
+	return first + uint16(lowest), statuses //nolint:gosec // G115
+}
+
+func tccStatusSymbol(status tccStatus) uint16 {
+	if status.withoutDelta {
+		return TypeTCCPacketReceivedWithoutDelta
+	}
+
+	return TypeTCCPacketNotReceived
+}
+
+// tccEncodeChunks encodes packet status symbols in as few chunks as
+// possible, choosing at each step the chunk that covers the most symbols.
+func tccEncodeChunks(symbols []uint16) []PacketStatusChunk {
+	var chunks []PacketStatusChunk
+	for len(symbols) > 0 {
+		run := 1
+		for run < len(symbols) && run < tccMaxRunLength && symbols[run] == symbols[0] {
+			run++
+		}
+
+		// Vector chunks always cover a full vector, except at the end
+		oneBit := 0
+		for oneBit < len(symbols) && oneBit < tccOneBitVectorLength && symbols[oneBit] <= TypeTCCPacketReceivedSmallDelta {
+			oneBit++
+		}
+		if oneBit < tccOneBitVectorLength && oneBit < len(symbols) {
+			oneBit = 0
+		}
+		twoBit := min(len(symbols), tccTwoBitVectorLength)
+
+		switch {
+		case run >= oneBit && run >= twoBit:
+			chunks = append(chunks, &RunLengthChunk{
+				Type:               TypeTCCRunLengthChunk,
+				PacketStatusSymbol: symbols[0],
+				RunLength:          uint16(run), //nolint:gosec // G115
+			})
+			symbols = symbols[run:]
+		case oneBit >= twoBit:
+			chunks = append(chunks, tccVectorChunk(TypeTCCSymbolSizeOneBit, symbols[:oneBit], tccOneBitVectorLength))
+			symbols = symbols[oneBit:]
+		default:
+			chunks = append(chunks, tccVectorChunk(TypeTCCSymbolSizeTwoBit, symbols[:twoBit], tccTwoBitVectorLength))
+			symbols = symbols[twoBit:]
+		}
+	}
+
+	return chunks
+}
+
+// tccVectorChunk returns a status vector chunk, padded with packets not
+// received.
+func tccVectorChunk(symbolSize uint16, symbols []uint16, length int) *StatusVectorChunk {
+	symbolList := make([]uint16, length)
+	copy(symbolList, symbols)
+
+	return &StatusVectorChunk{
+		Type:       TypeTCCStatusVectorChunk,
+		SymbolSize: symbolSize,
+		SymbolList: symbolList,
+	}
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Checks that a packet survives a round trip.
+func checkTransportLayerCC(t *testing.T, packet *TransportLayerCC, maxSize int) {
+	t.Helper()
+
+	raw, err := packet.Marshal()
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(raw), maxSize)
+
+	var decoded TransportLayerCC
+	assert.NoError(t, decoded.Unmarshal(raw))
+	assert.Equal(t, *packet, decoded)
+}
+
+func TestTransportLayerCCBuilder(t *testing.T) {
+	builder := NewTransportLayerCCBuilder(1, 2, 1200)
+
+	packets, err := builder.Build([]TransportLayerCCArrival{
+		{SequenceNumber: 100, Received: true, ArrivalTime: 1000 * time.Millisecond},
+		{SequenceNumber: 101, Received: true, ArrivalTime: 1001 * time.Millisecond},
+		{SequenceNumber: 103, Received: true, ArrivalTime: 1010*time.Millisecond + 100*time.Microsecond},
+		{SequenceNumber: 104, Received: true, ArrivalTime: 900 * time.Millisecond},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*TransportLayerCC{{
+		Header: Header{
+			Padding: true,
+			Count:   FormatTCC,
+			Type:    TypeTransportSpecificFeedback,
+			Length:  6,
+		},
+		SenderSSRC:         1,
+		MediaSSRC:          2,
+		BaseSequenceNumber: 100,
+		PacketStatusCount:  5,
+		// 960ms
+		ReferenceTime: 15,
+		FbPktCount:    0,
+		PacketChunks: []PacketStatusChunk{
+			&StatusVectorChunk{
+				Type:       TypeTCCStatusVectorChunk,
+				SymbolSize: TypeTCCSymbolSizeTwoBit,
+				SymbolList: []uint16{
+					TypeTCCPacketReceivedSmallDelta, TypeTCCPacketReceivedSmallDelta, TypeTCCPacketNotReceived,
+					TypeTCCPacketReceivedSmallDelta, TypeTCCPacketReceivedLargeDelta, TypeTCCPacketNotReceived,
+					TypeTCCPacketNotReceived,
+				},
+			},
+		},
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 40000},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 9000},
+			{Type: TypeTCCPacketReceivedLargeDelta, Delta: -110000},
+		},
+	}}, packets)
+	checkTransportLayerCC(t, packets[0], 1200)
+
+	// Packets are numbered
+	packets, err = builder.Build([]TransportLayerCCArrival{{SequenceNumber: 105, Received: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), packets[0].FbPktCount)
+
+	packets, err = builder.Build(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, packets)
+}
+
+func TestTransportLayerCCBuilderChunks(t *testing.T) {
+	var arrivals []TransportLayerCCArrival
+	arrival := 10 * time.Second
+	// A run of received packets
+	for seq := uint16(0); seq < 100; seq++ {
+		arrivals = append(arrivals, TransportLayerCCArrival{SequenceNumber: seq, Received: true, ArrivalTime: arrival})
+		arrival += time.Millisecond
+	}
+	// Alternating losses
+	for seq := uint16(120); seq < 134; seq += 2 {
+		arrivals = append(arrivals, TransportLayerCCArrival{SequenceNumber: seq, Received: true, ArrivalTime: arrival})
+		arrival += time.Millisecond
+	}
+
+	packets, err := NewTransportLayerCCBuilder(1, 2, 0).Build(arrivals)
+	assert.NoError(t, err)
+	assert.Len(t, packets, 1)
+	assert.Equal(t, uint16(133), packets[0].PacketStatusCount)
+	assert.Equal(t, []PacketStatusChunk{
+		&RunLengthChunk{PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta, RunLength: 100},
+		&RunLengthChunk{PacketStatusSymbol: TypeTCCPacketNotReceived, RunLength: 20},
+		&StatusVectorChunk{
+			Type:       TypeTCCStatusVectorChunk,
+			SymbolSize: TypeTCCSymbolSizeOneBit,
+			SymbolList: []uint16{1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0},
+		},
+	}, packets[0].PacketChunks)
+	checkTransportLayerCC(t, packets[0], tccMaxPacketLength)
+}
+
+func TestTransportLayerCCBuilderWraparound(t *testing.T) {
+	packets, err := NewTransportLayerCCBuilder(1, 2, 0).Build([]TransportLayerCCArrival{
+		{SequenceNumber: 1, Received: true, ArrivalTime: 3 * time.Millisecond},
+		{SequenceNumber: 65534, Received: true, ArrivalTime: 0},
+		{SequenceNumber: 0, Received: false},
+		{SequenceNumber: 65535, Received: true, ArrivalTime: time.Millisecond},
+		{SequenceNumber: 65535, Received: false},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, packets, 1)
+	assert.Equal(t, uint16(65534), packets[0].BaseSequenceNumber)
+	assert.Equal(t, uint16(4), packets[0].PacketStatusCount)
+	assert.Equal(t, map[uint16]int64{65534: 0, 65535: 1000, 1: 3000}, tccArrivals(t, packets[0]))
+}
+
+func TestTransportLayerCCBuilderDeltaOverflow(t *testing.T) {
+	packets, err := NewTransportLayerCCBuilder(1, 2, 0).Build([]TransportLayerCCArrival{
+		{SequenceNumber: 10, Received: true, ArrivalTime: time.Second},
+		{SequenceNumber: 11, Received: true, ArrivalTime: 10 * time.Second},
+		{SequenceNumber: 12, Received: true, ArrivalTime: 10*time.Second + time.Millisecond},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, packets, 2)
+
+	assert.Equal(t, uint16(10), packets[0].BaseSequenceNumber)
+	assert.Equal(t, uint16(1), packets[0].PacketStatusCount)
+	assert.Equal(t, uint8(0), packets[0].FbPktCount)
+	assert.Equal(t, uint16(11), packets[1].BaseSequenceNumber)
+	assert.Equal(t, uint16(2), packets[1].PacketStatusCount)
+	assert.Equal(t, uint8(1), packets[1].FbPktCount)
+	assert.Equal(t, uint32(10000/64), packets[1].ReferenceTime)
+	assert.Equal(t, map[uint16]int64{11: 10000000, 12: 10001000}, tccArrivals(t, packets[1]))
+}
+
+func TestTransportLayerCCBuilderSizeLimit(t *testing.T) {
+	var arrivals []TransportLayerCCArrival
+	expected := map[uint16]int64{}
+	arrival := time.Duration(0)
+	for seq := uint16(0); seq < 300; seq++ {
+		arrival += 700 * time.Microsecond
+		if seq%3 == 1 {
+			continue
+		}
+		arrivals = append(arrivals, TransportLayerCCArrival{SequenceNumber: seq, Received: true, ArrivalTime: arrival})
+		// Quantized to 250us
+		expected[seq] = arrival.Microseconds() / 250 * 250
+	}
+
+	packets, err := NewTransportLayerCCBuilder(1, 2, 100).Build(arrivals)
+	assert.NoError(t, err)
+	assert.Greater(t, len(packets), 2)
+
+	arrivalsBySeq := map[uint16]int64{}
+	nextSequenceNumber := uint16(0)
+	for i, packet := range packets {
+		assert.Equal(t, uint8(i), packet.FbPktCount) //nolint:gosec // G115
+		assert.Equal(t, nextSequenceNumber, packet.BaseSequenceNumber)
+		nextSequenceNumber += packet.PacketStatusCount
+		checkTransportLayerCC(t, packet, 100)
+
+		for seq, arrival := range tccArrivals(t, packet) {
+			arrivalsBySeq[seq] = arrival
+		}
+	}
+	assert.Equal(t, uint16(300), nextSequenceNumber)
+	assert.Equal(t, expected, arrivalsBySeq)
+
+	_, err = NewTransportLayerCCBuilder(1, 2, 20).Build(arrivals)
+	assert.ErrorIs(t, err, errTCCSizeTooSmall)
+}
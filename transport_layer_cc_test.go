@@ -5,6 +5,7 @@ package rtcp
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -1165,3 +1166,58 @@ func TestTransportLayerCC_Marshal(t *testing.T) {
 		})
 	}
 }
+
+func TestTransportLayerCC_Arrivals(t *testing.T) {
+	packet := TransportLayerCC{
+		BaseSequenceNumber: 65534,
+		PacketStatusCount:  6,
+		ReferenceTime:      2,
+		PacketChunks: []PacketStatusChunk{
+			&RunLengthChunk{PacketStatusSymbol: TypeTCCPacketReceivedWithoutDelta, RunLength: 2},
+			&StatusVectorChunk{
+				SymbolSize: TypeTCCSymbolSizeTwoBit,
+				SymbolList: []uint16{
+					TypeTCCPacketReceivedSmallDelta, TypeTCCPacketNotReceived, TypeTCCPacketReceivedLargeDelta,
+					TypeTCCPacketReceivedSmallDelta, TypeTCCPacketNotReceived, TypeTCCPacketNotReceived,
+					TypeTCCPacketNotReceived,
+				},
+			},
+		},
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+			{Type: TypeTCCPacketReceivedLargeDelta, Delta: -2000},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+		},
+	}
+
+	arrivals, err := packet.Arrivals()
+	assert.NoError(t, err)
+	assert.Equal(t, []TransportLayerCCArrival{
+		{SequenceNumber: 65534, Received: true, WithoutDelta: true},
+		{SequenceNumber: 65535, Received: true, WithoutDelta: true},
+		{SequenceNumber: 0, Received: true, ArrivalTime: 129 * time.Millisecond},
+		{SequenceNumber: 1},
+		{SequenceNumber: 2, Received: true, ArrivalTime: 127 * time.Millisecond},
+		{SequenceNumber: 3, Received: true, ArrivalTime: 127*time.Millisecond + 250*time.Microsecond},
+	}, arrivals)
+
+	// Round trip through the builder
+	packets, err := NewTransportLayerCCBuilder(1, 2, 0).Build(arrivals)
+	assert.NoError(t, err)
+	assert.Len(t, packets, 1)
+	rebuilt, err := packets[0].Arrivals()
+	assert.NoError(t, err)
+	assert.Equal(t, arrivals, rebuilt)
+
+	packet.RecvDeltas = packet.RecvDeltas[:2]
+	_, err = packet.Arrivals()
+	assert.ErrorIs(t, err, errTCCDeltasMismatch)
+
+	packet.RecvDeltas = append(packet.RecvDeltas, &RecvDelta{}, &RecvDelta{})
+	_, err = packet.Arrivals()
+	assert.ErrorIs(t, err, errTCCDeltasMismatch)
+
+	packet.PacketStatusCount = 10
+	_, err = packet.Arrivals()
+	assert.ErrorIs(t, err, errTCCDeltasMismatch)
+}